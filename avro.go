@@ -0,0 +1,80 @@
+package enumhelper
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteAvroSchema writes an Apache Avro JSON schema describing this enum type
+// as an Avro "enum".  Each symbol is the GoName (or Name, if GoName is empty)
+// of an enum value in UPPER_SNAKE_CASE, as for WriteProto.  Returns
+// InvalidIdentifierError if a value's name does not convert to a valid Avro
+// symbol, or DuplicateNameError if two values' names convert to the same
+// symbol; nothing is written in either case.
+func (enum EnumType) WriteAvroSchema(w io.Writer) error {
+	seen := make(map[string]struct{}, len(enum.Data))
+	symbols := make([]string, 0, len(enum.Data))
+	for _, ptr := range enum.Data {
+		symbol := protoEnumValueName(*ptr)
+		if err := checkIdentifier(enum.Type, ptr.canonicalName(), symbol, seen); err != nil {
+			return err
+		}
+		symbols = append(symbols, symbol)
+	}
+
+	schema := struct {
+		Type    string   `json:"type"`
+		Name    string   `json:"name"`
+		Symbols []string `json:"symbols"`
+	}{
+		Type:    "enum",
+		Name:    enum.Type,
+		Symbols: symbols,
+	}
+
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	raw = append(raw, '\n')
+
+	_, err = w.Write(raw)
+	return err
+}
+
+// WriteAvroSchema writes an Apache Avro JSON schema describing this bitfield
+// type.
+//
+// Avro has no bitfield type, so the schema is a "long" whose "doc" attribute
+// lists the meaning of each named bit.
+func (bitfield BitfieldType) WriteAvroSchema(w io.Writer) error {
+	pieces := make([]string, 0, len(bitfield.Names))
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		name := data.canonicalName()
+		if name == "" {
+			return
+		}
+		pieces = append(pieces, "bit "+strconv.FormatUint(uint64(data.Index), 10)+" = "+name)
+	})
+
+	schema := struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+		Doc  string `json:"doc"`
+	}{
+		Type: "long",
+		Name: bitfield.Type,
+		Doc:  bitfield.Type + " bitfield: " + strings.Join(pieces, ", "),
+	}
+
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	raw = append(raw, '\n')
+
+	_, err = w.Write(raw)
+	return err
+}
@@ -0,0 +1,64 @@
+package enumhelper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEnumType_WriteAvroSchema(t *testing.T) {
+	var buf bytes.Buffer
+	color := MakeEnumType("Color", testColorData)
+	if err := color.WriteAvroSchema(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var schema struct {
+		Type    string   `json:"type"`
+		Name    string   `json:"name"`
+		Symbols []string `json:"symbols"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &schema); err != nil {
+		t.Fatalf("output %q is not valid JSON: %v", buf.String(), err)
+	}
+	expected := []string{"COLOR_RED", "COLOR_GREEN", "COLOR_BLUE", "COLOR_PURPLE"}
+	if schema.Type != "enum" || schema.Name != "Color" || strings.Join(schema.Symbols, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected enum Color %v, got %+v", expected, schema)
+	}
+
+	buf.Reset()
+	bad := MakeEnumType("Bad", []EnumData{{GoName: "", Name: "9lives"}})
+	if err := bad.WriteAvroSchema(&buf); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("WriteAvroSchema(9lives): expected InvalidIdentifierError, got %#v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("WriteAvroSchema(9lives): expected no output, got %q", buf.String())
+	}
+}
+
+func TestBitfieldType_WriteAvroSchema(t *testing.T) {
+	var buf bytes.Buffer
+	perm := MakeBitfieldType("Perm", testPermData)
+	if err := perm.WriteAvroSchema(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var schema struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+		Doc  string `json:"doc"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &schema); err != nil {
+		t.Fatalf("output %q is not valid JSON: %v", buf.String(), err)
+	}
+	if schema.Type != "long" || schema.Name != "Perm" {
+		t.Errorf("expected long Perm, got %+v", schema)
+	}
+	for _, piece := range []string{"bit 0 = exec", "bit 1 = write", "bit 2 = read"} {
+		if !strings.Contains(schema.Doc, piece) {
+			t.Errorf("doc %q is missing %q", schema.Doc, piece)
+		}
+	}
+}
@@ -1,13 +1,19 @@
 package enumhelper
 
+//go:generate go run gen_bitfield.go
+
 import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"sort"
 	"strconv"
 	"strings"
-
-	"github.com/hashicorp/go-multierror"
+	"sync"
+	"sync/atomic"
 )
 
 // BitfieldData holds data about one particular bitfield bit.
@@ -22,13 +28,46 @@ type BitfieldData struct {
 	//
 	// Optional.
 	Aliases []string
+
+	// Description is a human-readable description of this bit, for use in
+	// documentation and help text.
+	//
+	// Optional.
+	Description string
+}
+
+// canonicalName returns Name, or GoName if Name is empty.
+func (data BitfieldData) canonicalName() string {
+	if data.Name != "" {
+		return data.Name
+	}
+	return data.GoName
+}
+
+// allNames returns GoName, Name, and Aliases, omitting any that are empty.
+func (data BitfieldData) allNames() []string {
+	out := make([]string, 0, 2+len(data.Aliases))
+	if data.GoName != "" {
+		out = append(out, data.GoName)
+	}
+	if data.Name != "" {
+		out = append(out, data.Name)
+	}
+	for _, alias := range data.Aliases {
+		if alias != "" {
+			out = append(out, alias)
+		}
+	}
+	return out
 }
 
 // AnnotatedBitfieldData extends BitfieldData with some auto-populated fields.
 type AnnotatedBitfieldData struct {
 	BitfieldData
 
-	// Index is the index of this bit; always between 0 and 63.
+	// Index is the index of this bit; always less than the width of the
+	// bitfield type, which is 64 unless it was created by MakeBitfieldType8,
+	// MakeBitfieldType16, or MakeBitfieldType32.
 	Index uint
 
 	// Bit is the value of this bit; always equal to (1 << Index).
@@ -40,31 +79,107 @@ type BitfieldType struct {
 	// Type gives the Go name for this bitfield type.
 	Type string
 
-	// Data lists the data for all known bits.
+	// Data lists the data for all known bits.  Its length is the width of
+	// the bitfield type.
 	Data []*AnnotatedBitfieldData
 
 	// Names holds some valid example names for the bitfield bits, if any.
 	Names []string
 
 	// ByName maps valid names to the data for the corresponding bit.
+	//
+	// ByName is modified by RegisterAlias and DeregisterAlias; callers
+	// which use those methods must not access ByName directly.
 	ByName map[string]*AnnotatedBitfieldData
+
+	opts  bitfieldOptions
+	reg   *aliasRegistry
+	known uint64
+}
+
+// aliasRegistry holds the mutable state shared by all copies of an EnumType
+// or BitfieldType that were created from the same call to MakeEnumType or
+// MakeBitfieldType.
+type aliasRegistry struct {
+	mu      sync.RWMutex
+	aliases map[string]struct{}
+	frozen  uint32
+}
+
+// rlock acquires a read lock, unless the registry is frozen and therefore
+// needs no locking.  The return value must be passed to runlock.
+func (reg *aliasRegistry) rlock() bool {
+	if reg == nil || atomic.LoadUint32(&reg.frozen) != 0 {
+		return false
+	}
+	reg.mu.RLock()
+	return true
+}
+
+func (reg *aliasRegistry) runlock(locked bool) {
+	if locked {
+		reg.mu.RUnlock()
+	}
+}
+
+// lockForWrite acquires the write lock, or panics if the registry is frozen.
+func (reg *aliasRegistry) lockForWrite(typeName string) {
+	reg.mu.Lock()
+	if reg.frozen != 0 {
+		reg.mu.Unlock()
+		panic(fmt.Errorf("%s is frozen", typeName))
+	}
+}
+
+// bitfieldOptions holds the optional behaviors configured by the
+// BitfieldType.With* methods.
+type bitfieldOptions struct {
+	encodedAs      string
+	unknownBitMode UnknownBitMode
+	maxReadSize    int64
+	validate       func(value uint64) error
 }
 
+// UnknownBitMode selects how a BitfieldType treats numeric values which set
+// bits that have no name.
+type UnknownBitMode int
+
+const (
+	// UnknownBitIsAccepted retains unknown bits in the parsed value.  This
+	// is the default.
+	UnknownBitIsAccepted UnknownBitMode = iota
+
+	// UnknownBitIsIgnored silently clears unknown bits from the parsed
+	// value.
+	UnknownBitIsIgnored
+
+	// UnknownBitIsError rejects numeric values with unknown bits by
+	// returning InvalidBitfieldNameError.
+	UnknownBitIsError
+)
+
 // MakeBitfieldType initializes and returns a BitfieldType.
 func MakeBitfieldType(typeName string, in []BitfieldData) BitfieldType {
+	return makeBitfieldType(typeName, in, 64)
+}
+
+// makeBitfieldType initializes and returns a BitfieldType with the given
+// number of bits.  Entries in in beyond width are ignored.
+func makeBitfieldType(typeName string, in []BitfieldData, width uint) BitfieldType {
 	length := uint(len(in))
-	if length > 64 {
-		length = 64
+	if length > width {
+		length = width
 	}
 
 	out := BitfieldType{
 		Type:   typeName,
-		Data:   make([]*AnnotatedBitfieldData, 64),
+		Data:   make([]*AnnotatedBitfieldData, width),
 		Names:  make([]string, 0, length),
 		ByName: make(map[string]*AnnotatedBitfieldData, 4*length),
+		reg:    new(aliasRegistry),
 	}
 
-	for index := uint(0); index < 64; index++ {
+	for index := uint(0); index < width; index++ {
 		var data BitfieldData
 		if index < length {
 			data = in[index]
@@ -81,11 +196,8 @@ func MakeBitfieldType(typeName string, in []BitfieldData) BitfieldType {
 			continue
 		}
 
-		name := data.Name
-		if name == "" {
-			name = data.GoName
-		}
-		out.Names = append(out.Names, name)
+		out.Names = append(out.Names, data.canonicalName())
+		out.known |= ptr.Bit
 
 		if data.GoName != "" {
 			out.ByName[data.GoName] = ptr
@@ -105,31 +217,146 @@ func MakeBitfieldType(typeName string, in []BitfieldData) BitfieldType {
 	return out
 }
 
+// width returns the number of bits in this bitfield type.
+func (bitfield BitfieldType) width() uint {
+	return uint(len(bitfield.Data))
+}
+
+// widthMask returns the OR of all bits which fit within the width of this
+// bitfield type, whether named or not.
+func (bitfield BitfieldType) widthMask() uint64 {
+	if w := bitfield.width(); w < 64 {
+		return (uint64(1) << w) - 1
+	}
+	return ^uint64(0)
+}
+
+// dataAt returns the data for the bit at the given index, or the zero
+// BitfieldData if index is beyond the width of this bitfield type.
+func (bitfield BitfieldType) dataAt(index uint) BitfieldData {
+	if index >= bitfield.width() {
+		return BitfieldData{}
+	}
+	return bitfield.Data[index].BitfieldData
+}
+
 // Get returns bitfield.Data[index] or panics with InvalidBitfieldIndexError.
 func (bitfield BitfieldType) Get(index uint) AnnotatedBitfieldData {
-	if index >= 64 {
+	if width := bitfield.width(); index >= width {
 		panic(InvalidBitfieldIndexError{
 			Type:  bitfield.Type,
 			Index: index,
-			Limit: 64,
+			Limit: width,
 		})
 	}
 	return *bitfield.Data[index]
 }
 
+// ContainsBit returns true iff index is within the width of this bitfield
+// type and the bit at that index has a name.
+func (bitfield BitfieldType) ContainsBit(index uint) bool {
+	if index >= bitfield.width() {
+		return false
+	}
+	data := bitfield.Data[index]
+	return data.GoName != "" || data.Name != ""
+}
+
 // ForEach iterates over bitfield.Data with the given callback function.
 func (bitfield BitfieldType) ForEach(fn func(data AnnotatedBitfieldData)) {
-	for index := uint(0); index < 64; index++ {
+	for _, data := range bitfield.Data {
+		fn(*data)
+	}
+}
+
+// Traverse calls fn for each bit in order of increasing index, starting with
+// startIndex, until fn returns false.  Panics with InvalidBitfieldIndexError
+// if startIndex is out of range.
+func (bitfield BitfieldType) Traverse(startIndex uint, fn func(index uint, data AnnotatedBitfieldData) bool) {
+	bitfield.Get(startIndex)
+	for _, data := range bitfield.Data[startIndex:] {
+		if !fn(data.Index, *data) {
+			return
+		}
+	}
+}
+
+// DescribeBit returns the Description of the bit at the given index, or its
+// canonical name if it has no Description.  Returns the empty string if the
+// bit has neither.
+func (bitfield BitfieldType) DescribeBit(index uint) string {
+	data := bitfield.dataAt(index)
+	if data.Description != "" {
+		return data.Description
+	}
+	return data.canonicalName()
+}
+
+// ForEachInMask calls fn for each named bit which is set in mask, in order of
+// increasing index.  Unset and unnamed bits are skipped without being
+// visited.
+func (bitfield BitfieldType) ForEachInMask(mask uint64, fn func(data AnnotatedBitfieldData)) {
+	mask &= bitfield.known
+	for mask != 0 {
+		index := bits.TrailingZeros64(mask)
 		fn(*bitfield.Data[index])
+		mask &= mask - 1
 	}
 }
 
-func (bitfield BitfieldType) toStringImpl(
+// AllAliasesForBit returns every string which FromString resolves to the bit
+// at the given index: its Name, GoName, and aliases, including those added by
+// RegisterAlias, followed by their lower case forms, without duplicates.
+// Returns nil if the bit has no name or index is out of range.
+func (bitfield BitfieldType) AllAliasesForBit(index uint) []string {
+	if !bitfield.ContainsBit(index) {
+		return nil
+	}
+
+	defer bitfield.reg.runlock(bitfield.reg.rlock())
+
+	names := bitfield.Data[index].allNames()
+	registered := make([]string, 0, len(bitfield.reg.aliases))
+	for alias := range bitfield.reg.aliases {
+		if bitfield.ByName[alias].Index == index {
+			registered = append(registered, alias)
+		}
+	}
+	sort.Strings(registered)
+	names = append(names, registered...)
+
+	return collectAliases(names, func(name string) bool {
+		data, found := bitfield.ByName[name]
+		return found && data.Index == index
+	})
+}
+
+// SetBits returns the data for each named bit which is set in value, in order
+// of increasing index.  Set bits which have no name are omitted.
+func (bitfield BitfieldType) SetBits(value uint64) []AnnotatedBitfieldData {
+	var out []AnnotatedBitfieldData
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		if (value&data.Bit) != 0 && (data.GoName != "" || data.Name != "") {
+			out = append(out, data)
+		}
+	})
+	return out
+}
+
+// rebuild returns a new BitfieldType of the same width with the given bits,
+// carrying over the options configured on this bitfield type.
+func (bitfield BitfieldType) rebuild(in []BitfieldData) BitfieldType {
+	out := makeBitfieldType(bitfield.Type, in, bitfield.width())
+	out.opts = bitfield.opts
+	return out
+}
+
+func (bitfield BitfieldType) toPiecesImpl(
 	value uint64,
 	fn1 func(data AnnotatedBitfieldData) string,
 	fn2 func(remnant uint64) string,
-) string {
-	pieces := make([]string, 0, 64)
+) []string {
+	pieces := make([]string, 0, len(bitfield.Data))
 	remnant := uint64(0)
 	bitfield.ForEach(func(data AnnotatedBitfieldData) {
 		if (value & data.Bit) != 0 {
@@ -146,7 +373,15 @@ func (bitfield BitfieldType) toStringImpl(
 			pieces = append(pieces, str)
 		}
 	}
-	return strings.Join(pieces, "|")
+	return pieces
+}
+
+func (bitfield BitfieldType) toStringImpl(
+	value uint64,
+	fn1 func(data AnnotatedBitfieldData) string,
+	fn2 func(remnant uint64) string,
+) string {
+	return strings.Join(bitfield.toPiecesImpl(value, fn1, fn2), "|")
 }
 
 // ToGoString generates a Go string representation for the given bitfield value.
@@ -165,9 +400,23 @@ func (bitfield BitfieldType) ToGoString(value uint64) string {
 	)
 }
 
+// GoString is an alias for ToGoString.
+func (bitfield BitfieldType) GoString(value uint64) string {
+	return bitfield.ToGoString(value)
+}
+
 // ToString generates a string representation for the given bitfield value.
 func (bitfield BitfieldType) ToString(value uint64) string {
-	return bitfield.toStringImpl(
+	return strings.Join(bitfield.toStringPieces(value), "|")
+}
+
+// String is an alias for ToString.
+func (bitfield BitfieldType) String(value uint64) string {
+	return bitfield.ToString(value)
+}
+
+func (bitfield BitfieldType) toStringPieces(value uint64) []string {
+	return bitfield.toPiecesImpl(
 		value,
 		func(data AnnotatedBitfieldData) string {
 			return data.Name
@@ -181,9 +430,129 @@ func (bitfield BitfieldType) ToString(value uint64) string {
 	)
 }
 
-// ToJSON marshals this bitfield value to JSON.
+// ToJSON marshals this bitfield value to JSON, using the format selected by
+// WithEncodedAs.
 func (bitfield BitfieldType) ToJSON(value uint64) ([]byte, error) {
-	return json.Marshal(bitfield.ToString(value))
+	switch bitfield.opts.encodedAs {
+	case "array":
+		return bitfield.ToJSONArray(value)
+	case "integer":
+		return json.Marshal(value)
+	default:
+		return json.Marshal(bitfield.ToString(value))
+	}
+}
+
+// ToJSONArray marshals this bitfield value to a JSON array of bit names, such
+// as ["read","write"].  Unnamed bits, if any, are represented by a final
+// hexadecimal item.  The zero value marshals to [].
+func (bitfield BitfieldType) ToJSONArray(value uint64) ([]byte, error) {
+	if value == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(bitfield.toStringPieces(value))
+}
+
+// WithEncodedAs returns a copy of this bitfield type whose ToJSON method uses
+// the given format.  The supported formats are:
+//
+//	"pipe"     a pipe-delimited string such as "read|write" (the default)
+//	"array"    an array of strings such as ["read", "write"]
+//	"integer"  a JSON number
+//
+// FromJSON accepts all three formats regardless of this setting.  Panics if
+// format is not one of the above.
+func (bitfield BitfieldType) WithEncodedAs(format string) BitfieldType {
+	switch format {
+	case "pipe", "array", "integer":
+		// pass
+	default:
+		panic(fmt.Errorf("unknown bitfield encoding %q", format))
+	}
+	out := bitfield
+	out.opts.encodedAs = format
+	return out
+}
+
+// WithValidationHook returns a copy of this bitfield type whose FromString,
+// FromJSON, FromYAML, and ScanBitfield methods, and whose flags, call fn with
+// each value that they resolve.  If fn returns an error, the method returns
+// that error.  Inputs made of several items, such as YAML sequences or
+// repeated flags, are validated once as a whole.
+func (bitfield BitfieldType) WithValidationHook(fn func(value uint64) error) BitfieldType {
+	out := bitfield
+	out.opts.validate = fn
+	return out
+}
+
+// validate calls the WithValidationHook function, if any, and returns u64 if
+// it succeeds.
+func (bitfield BitfieldType) validate(u64 uint64) (uint64, error) {
+	if bitfield.opts.validate != nil {
+		if err := bitfield.opts.validate(u64); err != nil {
+			return 0, err
+		}
+	}
+	return u64, nil
+}
+
+// WithUnknownBitBehavior returns a copy of this bitfield type whose FromString
+// and FromJSON methods handle unknown bits according to mode.
+func (bitfield BitfieldType) WithUnknownBitBehavior(mode UnknownBitMode) BitfieldType {
+	out := bitfield
+	out.opts.unknownBitMode = mode
+	return out
+}
+
+// KnownBits returns the OR of all bits which have a name.
+func (bitfield BitfieldType) KnownBits() uint64 {
+	return bitfield.known
+}
+
+// UnknownBits returns the bits which are set in value but have no name.
+func (bitfield BitfieldType) UnknownBits(value uint64) uint64 {
+	return value &^ bitfield.known
+}
+
+// IsFullyKnown returns true iff every bit which is set in value has a name.
+func (bitfield BitfieldType) IsFullyKnown(value uint64) bool {
+	return bitfield.UnknownBits(value) == 0
+}
+
+// PopCount returns the number of named bits which are set in value.  Unlike
+// bits.OnesCount64, bits which have no name are not counted.
+func (bitfield BitfieldType) PopCount(value uint64) int {
+	return bits.OnesCount64(value & bitfield.known)
+}
+
+// checkUnknownBits applies the configured UnknownBitMode to a numeric value.
+// Values which do not fit within the width of the bitfield type are always
+// rejected.
+func (bitfield BitfieldType) checkUnknownBits(u64 uint64) (uint64, bool) {
+	if (u64 &^ bitfield.widthMask()) != 0 {
+		return u64, false
+	}
+
+	switch bitfield.opts.unknownBitMode {
+	case UnknownBitIsIgnored:
+		return u64 & bitfield.KnownBits(), true
+	case UnknownBitIsError:
+		return u64, (u64 &^ bitfield.KnownBits()) == 0
+	default:
+		return u64, true
+	}
+}
+
+// lookupName looks up str in ByName, first as-is and then in lower case.
+func (bitfield BitfieldType) lookupName(str string) (*AnnotatedBitfieldData, bool) {
+	defer bitfield.reg.runlock(bitfield.reg.rlock())
+
+	if data, found := bitfield.ByName[str]; found {
+		return data, true
+	}
+
+	data, found := bitfield.ByName[strings.ToLower(str)]
+	return data, found
 }
 
 func (bitfield BitfieldType) parseItem(str string) (uint64, bool) {
@@ -195,12 +564,7 @@ func (bitfield BitfieldType) parseItem(str string) (uint64, bool) {
 		str = str[i:j]
 	}
 
-	if data, found := bitfield.ByName[str]; found {
-		return data.Bit, true
-	}
-
-	strLower := strings.ToLower(str)
-	if data, found := bitfield.ByName[strLower]; found {
+	if data, found := bitfield.lookupName(str); found {
 		return data.Bit, true
 	}
 
@@ -209,27 +573,41 @@ func (bitfield BitfieldType) parseItem(str string) (uint64, bool) {
 	}
 
 	if u64, err := strconv.ParseUint(str, 0, 64); err == nil {
-		return u64, true
+		return bitfield.checkUnknownBits(u64)
 	}
 
 	return 0, false
 }
 
 // FromString parses the string representation of a bitfield value.  Returns
-// InvalidBitfieldNameError if the string cannot be parsed.
+// InvalidBitfieldNameError if the string cannot be parsed, or
+// MultiBitfieldParseError if more than one of its pipe-delimited items cannot
+// be parsed.  Returns the error returned by the WithValidationHook function
+// if the string is parsed but fails validation.
 func (bitfield BitfieldType) FromString(str string) (uint64, error) {
+	u64, err := bitfield.parse(str)
+	if err != nil {
+		return 0, err
+	}
+	return bitfield.validate(u64)
+}
+
+// parse is like FromString, but does not call the WithValidationHook
+// function.  Callers which combine several parsed values should validate
+// the combined value instead.
+func (bitfield BitfieldType) parse(str string) (uint64, error) {
 	if u64, ok := bitfield.parseItem(str); ok {
 		return u64, nil
 	}
 
 	accum := uint64(0)
 	pieces := strings.Split(str, "|")
-	errors := []error(nil)
+	var errs []InvalidBitfieldNameError
 	for _, piece := range pieces {
 		if u64, ok := bitfield.parseItem(piece); ok {
 			accum |= u64
 		} else {
-			errors = append(errors, InvalidBitfieldNameError{
+			errs = append(errs, InvalidBitfieldNameError{
 				Type:    bitfield.Type,
 				Name:    piece,
 				Allowed: bitfield.Names,
@@ -237,20 +615,149 @@ func (bitfield BitfieldType) FromString(str string) (uint64, error) {
 		}
 	}
 
-	if len(errors) == 0 {
+	if len(errs) == 0 {
 		return accum, nil
 	}
 
-	if len(errors) == 1 {
-		return 0, errors[0]
+	if len(errs) == 1 {
+		return 0, errs[0]
 	}
 
-	return 0, &multierror.Error{Errors: errors}
+	return 0, MultiBitfieldParseError{
+		Type:   bitfield.Type,
+		Errors: errs,
+	}
+}
+
+// FromStringOrZero is like FromString, but returns 0 if the string cannot be
+// parsed.
+func (bitfield BitfieldType) FromStringOrZero(str string) uint64 {
+	u64, err := bitfield.FromString(str)
+	if err != nil {
+		return 0
+	}
+	return u64
+}
+
+// FromStringOrMax is like FromString, but returns ^uint64(0) if the string
+// cannot be parsed.
+func (bitfield BitfieldType) FromStringOrMax(str string) uint64 {
+	u64, err := bitfield.FromString(str)
+	if err != nil {
+		return ^uint64(0)
+	}
+	return u64
+}
+
+// MustFromString is like FromString, but panics with the error if the string
+// cannot be parsed.  The error names the bitfield type, the rejected string,
+// and the allowed names.  MustFromString is intended only for use in tests
+// and init code.
+func (bitfield BitfieldType) MustFromString(str string) uint64 {
+	u64, err := bitfield.FromString(str)
+	if err != nil {
+		panic(err)
+	}
+	return u64
+}
+
+// MakeResolver returns a function which parses a string with FromString,
+// returning false instead of an error if the string cannot be parsed.
+func (bitfield BitfieldType) MakeResolver() func(string) (uint64, bool) {
+	return func(str string) (uint64, bool) {
+		u64, err := bitfield.FromString(str)
+		if err != nil {
+			return 0, false
+		}
+		return u64, true
+	}
+}
+
+// MakePredicate returns a function which returns true iff every bit in mask
+// is set in its argument.
+func (bitfield BitfieldType) MakePredicate(mask uint64) func(uint64) bool {
+	return func(value uint64) bool {
+		return (value & mask) == mask
+	}
+}
+
+// MakeAnyPredicate returns a function which returns true iff at least one bit
+// in mask is set in its argument.
+func (bitfield BitfieldType) MakeAnyPredicate(mask uint64) func(uint64) bool {
+	return func(value uint64) bool {
+		return (value & mask) != 0
+	}
+}
+
+// Set returns value with the given bit set.  Panics with
+// InvalidBitfieldIndexError if bit is not a single named bit.
+func (bitfield BitfieldType) Set(value, bit uint64) uint64 {
+	bitfield.checkBit(bit)
+	return value | bit
+}
+
+// Clear returns value with the given bit cleared.  Panics with
+// InvalidBitfieldIndexError if bit is not a single named bit.
+func (bitfield BitfieldType) Clear(value, bit uint64) uint64 {
+	bitfield.checkBit(bit)
+	return value &^ bit
+}
+
+// Toggle returns value with the given bit flipped.  Panics with
+// InvalidBitfieldIndexError if bit is not a single named bit.
+func (bitfield BitfieldType) Toggle(value, bit uint64) uint64 {
+	bitfield.checkBit(bit)
+	return value ^ bit
+}
+
+// checkBit panics with InvalidBitfieldIndexError unless bit is a power of two
+// whose index has a name.
+func (bitfield BitfieldType) checkBit(bit uint64) {
+	if bits.OnesCount64(bit) != 1 || (bit&bitfield.known) == 0 {
+		panic(InvalidBitfieldIndexError{
+			Type:  bitfield.Type,
+			Index: uint(bits.TrailingZeros64(bit)),
+			Limit: bitfield.width(),
+		})
+	}
+}
+
+// HasAll returns true iff every bit in mask is set in value.  Unlike
+// MakePredicate, HasAll returns false if mask is zero.
+func (bitfield BitfieldType) HasAll(value, mask uint64) bool {
+	return mask != 0 && (value&mask) == mask
+}
+
+// HasAny returns true iff at least one bit in mask is set in value.  HasAny
+// returns false if mask is zero.
+func (bitfield BitfieldType) HasAny(value, mask uint64) bool {
+	return (value & mask) != 0
+}
+
+// MaskFor returns the OR of the bits with the given names.  Unlike
+// FromString, each name must name a single bit; pipe-delimited lists and
+// numbers are not accepted.  Returns InvalidBitfieldNameError if any name is
+// not recognized.  Returns 0 if no names are given.
+func (bitfield BitfieldType) MaskFor(names ...string) (uint64, error) {
+	mask := uint64(0)
+	for _, name := range names {
+		data, found := bitfield.lookupName(name)
+		if !found {
+			return 0, InvalidBitfieldNameError{
+				Type:    bitfield.Type,
+				Name:    name,
+				Allowed: bitfield.Names,
+			}
+		}
+		mask |= data.Bit
+	}
+	return mask, nil
 }
 
 // FromJSON unmarshals a bitfield value from JSON.  Returns IsNullError or
 // InvalidBitfieldNameError if a JSON value was parsed but could not be
-// unmarshaled as an bitfield value.
+// unmarshaled as an bitfield value, or the error returned by the
+// WithValidationHook function.
 func (bitfield BitfieldType) FromJSON(raw []byte) (uint64, error) {
 	if raw == nil {
 		panic(errors.New("[]byte is nil"))
@@ -269,8 +776,315 @@ func (bitfield BitfieldType) FromJSON(raw []byte) (uint64, error) {
 	var u64 uint64
 	err1 := json.Unmarshal(raw, &u64)
 	if err1 == nil {
-		return u64, nil
+		if u64, ok := bitfield.checkUnknownBits(u64); ok {
+			return bitfield.validate(u64)
+		}
+		return 0, InvalidBitfieldNameError{
+			Type:    bitfield.Type,
+			Name:    string(raw),
+			Allowed: bitfield.Names,
+		}
+	}
+
+	var list []string
+	err2 := json.Unmarshal(raw, &list)
+	if err2 == nil && len(list) == 0 {
+		return bitfield.validate(0)
+	}
+	if err2 == nil {
+		return bitfield.FromString(strings.Join(list, "|"))
 	}
 
 	return 0, err0
 }
+
+// MustFromJSON is like FromJSON, but panics with the error if the JSON value
+// cannot be unmarshaled.
+func (bitfield BitfieldType) MustFromJSON(raw []byte) uint64 {
+	u64, err := bitfield.FromJSON(raw)
+	if err != nil {
+		panic(err)
+	}
+	return u64
+}
+
+// MakeConstMap returns a map from the GoName of each bit to its value.  Bits
+// without a GoName are omitted.
+func (bitfield BitfieldType) MakeConstMap() map[string]uint64 {
+	out := make(map[string]uint64, len(bitfield.Names))
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		if data.GoName != "" {
+			out[data.GoName] = data.Bit
+		}
+	})
+	return out
+}
+
+// MakeReverseMap returns a map from the value of each named bit to its
+// canonical string representation.
+func (bitfield BitfieldType) MakeReverseMap() map[uint64]string {
+	out := make(map[uint64]string, len(bitfield.Names))
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		if name := data.canonicalName(); name != "" {
+			out[data.Bit] = name
+		}
+	})
+	return out
+}
+
+// rawData returns a copy of the BitfieldData for all bits, suitable for
+// passing to rebuild.
+func (bitfield BitfieldType) rawData() []BitfieldData {
+	out := make([]BitfieldData, len(bitfield.Data))
+	for index, data := range bitfield.Data {
+		out[index] = data.BitfieldData
+	}
+	return out
+}
+
+// TruncateTo returns a copy of this bitfield type in which only the first n
+// named bits remain named.  Returns InvalidBitfieldIndexError if n exceeds
+// the number of named bits.
+func (bitfield BitfieldType) TruncateTo(n uint) (BitfieldType, error) {
+	if limit := uint(len(bitfield.Names)); n > limit {
+		return BitfieldType{}, InvalidBitfieldIndexError{
+			Type:  bitfield.Type,
+			Index: n,
+			Limit: limit,
+		}
+	}
+
+	in := bitfield.rawData()
+	count := uint(0)
+	for index := range in {
+		if in[index].GoName == "" && in[index].Name == "" {
+			continue
+		}
+		if count >= n {
+			in[index] = BitfieldData{}
+			continue
+		}
+		count++
+	}
+	return bitfield.rebuild(in), nil
+}
+
+// AddBit returns a copy of this bitfield type with the given bit added at the
+// given index.  It is equivalent to ExtendWith with a single bit.
+func (bitfield BitfieldType) AddBit(index uint, data BitfieldData) (BitfieldType, error) {
+	return bitfield.ExtendWith([]BitfieldData{data}, index)
+}
+
+// ExtendWith returns a copy of this bitfield type with the bits in extra
+// added, starting at bit index startIndex.  Empty entries in extra are
+// skipped, leaving the existing bit (if any) untouched.
+//
+// Returns InvalidBitfieldIndexError if a bit would be placed beyond the width
+// of the bitfield type,
+// DuplicateBitfieldIndexError if a bit would replace an existing named bit,
+// or DuplicateNameError if a new name conflicts with an existing one.
+func (bitfield BitfieldType) ExtendWith(extra []BitfieldData, startIndex uint) (BitfieldType, error) {
+	in := bitfield.rawData()
+	locked := bitfield.reg.rlock()
+	seen := make(map[string]struct{}, len(bitfield.ByName)+4*len(extra))
+	for name := range bitfield.ByName {
+		seen[strings.ToLower(name)] = struct{}{}
+	}
+	bitfield.reg.runlock(locked)
+
+	for i, data := range extra {
+		if data.GoName == "" && data.Name == "" {
+			continue
+		}
+
+		index := startIndex + uint(i)
+		if width := bitfield.width(); index >= width {
+			return BitfieldType{}, InvalidBitfieldIndexError{
+				Type:  bitfield.Type,
+				Index: index,
+				Limit: width,
+			}
+		}
+		if in[index].GoName != "" || in[index].Name != "" {
+			return BitfieldType{}, DuplicateBitfieldIndexError{
+				Type:  bitfield.Type,
+				Index: index,
+			}
+		}
+
+		names := data.allNames()
+		for _, name := range names {
+			if _, found := seen[strings.ToLower(name)]; found {
+				return BitfieldType{}, DuplicateNameError{
+					Type: bitfield.Type,
+					Name: name,
+				}
+			}
+		}
+		for _, name := range names {
+			seen[strings.ToLower(name)] = struct{}{}
+		}
+
+		in[index] = data
+	}
+	return bitfield.rebuild(in), nil
+}
+
+// Intersection returns a copy of this bitfield type in which a bit remains
+// named only if the bit at the same index in other has the same canonical
+// name.  Bit positions are preserved; all other bits become unnamed.
+func (bitfield BitfieldType) Intersection(other BitfieldType) BitfieldType {
+	in := bitfield.rawData()
+	for index := range in {
+		name := in[index].canonicalName()
+		if name == "" || name != other.dataAt(uint(index)).canonicalName() {
+			in[index] = BitfieldData{}
+		}
+	}
+	return bitfield.rebuild(in)
+}
+
+// DumpBits returns a string describing the given bitfield value, one
+// character per bit, with the highest bit first and bit 0 last.  The string
+// has 64 characters unless the bitfield type is narrower.  This is intended
+// for low-level debugging.
+//
+// Each character is one of:
+//
+//	'_'  the bit is not set and has no name
+//	'.'  the bit is not set but has a name
+//	'1'  the bit is set but has no name
+//	'A'  the bit is set and has a name; the character is the first letter of
+//	     the bit's canonical name, in upper case, or '*' if that is not an
+//	     ASCII letter or digit
+func (bitfield BitfieldType) DumpBits(value uint64) string {
+	width := bitfield.width()
+	buf := make([]byte, width)
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		name := data.canonicalName()
+		isSet := (value & data.Bit) != 0
+
+		var ch byte
+		switch {
+		case name == "" && !isSet:
+			ch = '_'
+		case !isSet:
+			ch = '.'
+		case name == "":
+			ch = '1'
+		default:
+			ch = name[0]
+			if ch >= 'a' && ch <= 'z' {
+				ch = ch - 'a' + 'A'
+			}
+			if !((ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')) {
+				ch = '*'
+			}
+		}
+		buf[width-1-data.Index] = ch
+	})
+	return string(buf)
+}
+
+// RegisterAlias adds alias as an additional name for the bit at the given
+// index.  It is safe to call concurrently with FromString and FromJSON.  The
+// bitfield type must have been created by MakeBitfieldType.
+//
+// The alias is visible to every copy of this bitfield type, but not to new
+// types derived from it by methods such as ExtendWith.  Returns
+// InvalidBitfieldIndexError if index is out of range, or DuplicateNameError
+// if alias is already in use.  Panics if the bitfield type has been frozen.
+func (bitfield BitfieldType) RegisterAlias(index uint, alias string) error {
+	if width := bitfield.width(); index >= width {
+		return InvalidBitfieldIndexError{
+			Type:  bitfield.Type,
+			Index: index,
+			Limit: width,
+		}
+	}
+
+	reg := bitfield.reg
+	reg.lockForWrite(bitfield.Type)
+	defer reg.mu.Unlock()
+
+	lower := strings.ToLower(alias)
+	_, found0 := bitfield.ByName[alias]
+	_, found1 := bitfield.ByName[lower]
+	if alias == "" || found0 || found1 {
+		return DuplicateNameError{
+			Type: bitfield.Type,
+			Name: alias,
+		}
+	}
+
+	if reg.aliases == nil {
+		reg.aliases = make(map[string]struct{})
+	}
+	reg.aliases[alias] = struct{}{}
+	ptr := bitfield.Data[index]
+	bitfield.ByName[alias] = ptr
+	bitfield.ByName[lower] = ptr
+	return nil
+}
+
+// DeregisterAlias removes an alias previously added by RegisterAlias.  It is
+// safe to call concurrently with FromString and FromJSON.  Returns
+// InvalidBitfieldNameError if alias was not registered by RegisterAlias.
+// Panics if the bitfield type has been frozen.
+func (bitfield BitfieldType) DeregisterAlias(alias string) error {
+	reg := bitfield.reg
+	reg.lockForWrite(bitfield.Type)
+	defer reg.mu.Unlock()
+
+	if _, found := reg.aliases[alias]; !found {
+		return InvalidBitfieldNameError{
+			Type: bitfield.Type,
+			Name: alias,
+		}
+	}
+
+	delete(reg.aliases, alias)
+	delete(bitfield.ByName, alias)
+	delete(bitfield.ByName, strings.ToLower(alias))
+	return nil
+}
+
+// Freeze marks this bitfield type, and every copy of it, as immutable.
+// After Freeze returns, RegisterAlias and DeregisterAlias will panic, and
+// lookups by name no longer need to acquire a lock.  Calling Freeze more than
+// once is harmless.
+func (bitfield BitfieldType) Freeze() {
+	reg := bitfield.reg
+	if reg == nil {
+		return
+	}
+	reg.mu.Lock()
+	atomic.StoreUint32(&reg.frozen, 1)
+	reg.mu.Unlock()
+}
+
+// WithMaxReadSize returns a copy of this bitfield type whose ParseFromReader
+// method reads at most n bytes.  If n <= 0, DefaultMaxReadSize is used.
+func (bitfield BitfieldType) WithMaxReadSize(n int64) BitfieldType {
+	out := bitfield
+	out.opts.maxReadSize = n
+	return out
+}
+
+// ParseFromReader reads all bytes from r, trims surrounding whitespace, and
+// parses the result with FromString.  Returns InputTooLargeError if r holds
+// more than the limit set by WithMaxReadSize.
+func (bitfield BitfieldType) ParseFromReader(r io.Reader) (uint64, error) {
+	str, err := readAllLimited(r, bitfield.Type, bitfield.opts.maxReadSize)
+	if err != nil {
+		return 0, err
+	}
+	return bitfield.FromString(str)
+}
+
+// FromJSONArray unmarshals a bitfield value from a JSON array of bit names,
+// as produced by ToJSONArray.  Like FromJSON, which it is equivalent to, it
+// also accepts a pipe-delimited string or a number.
+func (bitfield BitfieldType) FromJSONArray(raw []byte) (uint64, error) {
+	return bitfield.FromJSON(raw)
+}
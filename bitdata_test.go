@@ -0,0 +1,710 @@
+package enumhelper
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+var testPermData = []BitfieldData{
+	{GoName: "PermExec", Name: "exec", Aliases: []string{"x"}},
+	{GoName: "PermWrite", Name: "write", Aliases: []string{"w"}},
+	{GoName: "PermRead", Name: "read", Aliases: []string{"r"}},
+}
+
+var errReadWrite = errors.New("read and write are mutually exclusive")
+
+// rejectReadWrite is a validation hook which treats the read and write bits
+// as mutually exclusive.
+func rejectReadWrite(value uint64) error {
+	if (value & 0x6) == 0x6 {
+		return errReadWrite
+	}
+	return nil
+}
+
+func TestBitfieldType_WithValidationHook(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData).WithValidationHook(rejectReadWrite)
+
+	type testCase struct {
+		Name  string
+		Parse func() (uint64, error)
+		Value uint64
+		Err   error
+	}
+
+	fromYAML := func(str string) func() (uint64, error) {
+		return func() (uint64, error) {
+			var node yaml.Node
+			if err := yaml.Unmarshal([]byte(str), &node); err != nil {
+				return 0, err
+			}
+			return perm.FromYAML(&node)
+		}
+	}
+
+	scan := func(src interface{}) func() (uint64, error) {
+		return func() (uint64, error) {
+			var u64 uint64
+			err := perm.ScanBitfield(src, &u64)
+			return u64, err
+		}
+	}
+
+	testData := []testCase{
+		{"FromString/ok", func() (uint64, error) { return perm.FromString("read|exec") }, 0x5, nil},
+		{"FromString/rejected", func() (uint64, error) { return perm.FromString("READ|WRITE") }, 0, errReadWrite},
+		{"FromJSON/string", func() (uint64, error) { return perm.FromJSON([]byte(`"read|write"`)) }, 0, errReadWrite},
+		{"FromJSON/array", func() (uint64, error) { return perm.FromJSON([]byte(`["read","write"]`)) }, 0, errReadWrite},
+		{"FromJSON/number", func() (uint64, error) { return perm.FromJSON([]byte(`6`)) }, 0, errReadWrite},
+		{"FromYAML/sequence/ok", fromYAML(`[read, exec]`), 0x5, nil},
+		{"FromYAML/sequence/rejected", fromYAML(`[read, write]`), 0, errReadWrite},
+		{"FromYAML/scalar", fromYAML(`read|write`), 0, errReadWrite},
+		{"ScanBitfield/int64/ok", scan(int64(0x4)), 0x4, nil},
+		{"ScanBitfield/int64/rejected", scan(int64(0x6)), 0, errReadWrite},
+		{"ScanBitfield/string", scan("read|write"), 0, errReadWrite},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			value, err := row.Parse()
+			if !errors.Is(err, row.Err) {
+				t.Fatalf("expected error %v, got %v", row.Err, err)
+			}
+			if value != row.Value {
+				t.Errorf("expected value 0x%x, got 0x%x", row.Value, value)
+			}
+		})
+	}
+}
+
+func TestBitfieldType_WithValidationHook_Flag(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData).WithValidationHook(rejectReadWrite)
+
+	parse := func(args ...string) (uint64, error) {
+		var u64 uint64
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Var(perm.NewFlag(&u64), "p", perm.FlagUsage())
+		err := fs.Parse(args)
+		return u64, err
+	}
+
+	if value, err := parse("-p=read", "-p=exec"); err != nil || value != 0x5 {
+		t.Errorf("-p=read -p=exec: expected 0x5, nil; got 0x%x, %v", value, err)
+	}
+	// The flag package reports Set errors as text, so compare messages.
+	if _, err := parse("-p=read", "-p=write"); err == nil || !strings.Contains(err.Error(), errReadWrite.Error()) {
+		t.Errorf("-p=read -p=write: expected %v, got %v", errReadWrite, err)
+	}
+}
+
+func TestBitfieldType_WithValidationHook_Combined(t *testing.T) {
+	// The hook requires the exec bit, so each item on its own would fail.
+	errNoExec := errors.New("exec is required")
+	perm := MakeBitfieldType("Perm", testPermData).WithValidationHook(func(value uint64) error {
+		if (value & 0x1) == 0 {
+			return errNoExec
+		}
+		return nil
+	})
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(`[read, exec]`), &node); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := perm.FromYAML(&node); err != nil || value != 0x5 {
+		t.Errorf("FromYAML: expected 0x5, nil; got 0x%x, %v", value, err)
+	}
+	if _, err := perm.FromString("read"); !errors.Is(err, errNoExec) {
+		t.Errorf("FromString: expected %v, got %v", errNoExec, err)
+	}
+}
+
+func TestBitfieldType_ScanBitfield_UnknownBits(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	type testCase struct {
+		Name  string
+		Mode  UnknownBitMode
+		Src   int64
+		Value uint64
+		Err   error
+	}
+
+	testData := []testCase{
+		{"accepted", UnknownBitIsAccepted, 0xc, 0xc, nil},
+		{"ignored", UnknownBitIsIgnored, 0xc, 0x4, nil},
+		{"error/known", UnknownBitIsError, 0x4, 0x4, nil},
+		{"error/unknown", UnknownBitIsError, 0x8, 0, ErrInvalidBitfieldName},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var value uint64
+			err := perm.WithUnknownBitBehavior(row.Mode).ScanBitfield(row.Src, &value)
+			if !errors.Is(err, row.Err) {
+				t.Fatalf("expected error %v, got %v", row.Err, err)
+			}
+			if value != row.Value {
+				t.Errorf("expected value 0x%x, got 0x%x", row.Value, value)
+			}
+		})
+	}
+}
+
+// benchBitfield has sixteen named bits, enough for the ten-item benchmark
+// inputs below.
+var benchBitfield = func() BitfieldType {
+	data := make([]BitfieldData, 16)
+	for index := range data {
+		name := "bit" + strconv.Itoa(index)
+		data[index] = BitfieldData{GoName: "Bench" + name, Name: name}
+	}
+	return MakeBitfieldType("Bench", data)
+}()
+
+const benchBitfieldTenItems = "bit0|bit1|bit2|bit3|bit4|bit5|bit6|bit7|bit8|bit9"
+
+func TestBitfieldType_ZeroAllocs(t *testing.T) {
+	var sink uint64
+	allocs := testing.AllocsPerRun(100, func() {
+		u64, _ := benchBitfield.FromString("bit7")
+		sink += u64
+	})
+	if allocs != 0 {
+		t.Errorf("FromString: expected 0 allocs, got %v", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		benchBitfield.ForEachInMask(0xffff, func(data AnnotatedBitfieldData) {
+			sink += data.Bit
+		})
+	})
+	if allocs != 0 {
+		t.Errorf("ForEachInMask: expected 0 allocs, got %v", allocs)
+	}
+	_ = sink
+}
+
+func BenchmarkBitfieldFromString(b *testing.B) {
+	b.Run("single", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = benchBitfield.FromString("bit7")
+		}
+	})
+	b.Run("ten", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = benchBitfield.FromString(benchBitfieldTenItems)
+		}
+	})
+}
+
+func BenchmarkBitfieldToString(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = benchBitfield.ToString(0x3ff)
+	}
+}
+
+func BenchmarkBitfieldForEachSet(b *testing.B) {
+	b.ReportAllocs()
+	var count int
+	for i := 0; i < b.N; i++ {
+		benchBitfield.ForEachInMask(0x5555, func(data AnnotatedBitfieldData) {
+			count++
+		})
+	}
+	_ = count
+}
+
+func BenchmarkBitfieldAllBitsMarshal(b *testing.B) {
+	b.ReportAllocs()
+	all := benchBitfield.KnownBits()
+	for i := 0; i < b.N; i++ {
+		_, _ = benchBitfield.ToJSON(all)
+	}
+}
+
+func TestBitfieldType_RegisterAlias(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	if err := perm.RegisterAlias(2, "Readable"); err != nil {
+		t.Fatal(err)
+	}
+	for _, str := range []string{"Readable", "readable", "READABLE"} {
+		if value, err := perm.FromString(str); err != nil || value != 0x4 {
+			t.Errorf("FromString(%q): expected 0x4, nil; got 0x%x, %v", str, value, err)
+		}
+	}
+
+	if err := perm.RegisterAlias(1, "readable"); !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("RegisterAlias duplicate: expected %v, got %v", ErrDuplicateName, err)
+	}
+	if err := perm.RegisterAlias(64, "huge"); !errors.Is(err, ErrInvalidBitfieldIndex) {
+		t.Errorf("RegisterAlias out of range: expected %v, got %v", ErrInvalidBitfieldIndex, err)
+	}
+
+	if err := perm.DeregisterAlias("Readable"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := perm.FromString("readable"); !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("FromString after DeregisterAlias: expected %v, got %v", ErrInvalidBitfieldName, err)
+	}
+	if err := perm.DeregisterAlias("read"); !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("DeregisterAlias of a built-in name: expected %v, got %v", ErrInvalidBitfieldName, err)
+	}
+}
+
+func TestBitfieldType_RegisterAlias_Concurrent(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	const numAliases = 100
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numAliases; i++ {
+			alias := "alias" + strconv.Itoa(i)
+			if err := perm.RegisterAlias(uint(i%3), alias); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numAliases; i++ {
+			if value, err := perm.FromString("read|write"); err != nil || value != 0x6 {
+				t.Errorf("FromString: expected 0x6, nil; got 0x%x, %v", value, err)
+				return
+			}
+			_, _ = perm.FromString("alias" + strconv.Itoa(i))
+		}
+	}()
+	wg.Wait()
+
+	for i := 0; i < numAliases; i++ {
+		alias := "alias" + strconv.Itoa(i)
+		expect := uint64(1) << uint(i%3)
+		if value, err := perm.FromString(alias); err != nil || value != expect {
+			t.Errorf("FromString(%q): expected 0x%x, nil; got 0x%x, %v", alias, expect, value, err)
+		}
+	}
+}
+
+func TestBitfieldType_Freeze(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+	if err := perm.RegisterAlias(2, "readable"); err != nil {
+		t.Fatal(err)
+	}
+	perm.Freeze()
+	perm.Freeze()
+
+	if value, err := perm.FromString("readable|w"); err != nil || value != 0x6 {
+		t.Errorf("FromString: expected 0x6, nil; got 0x%x, %v", value, err)
+	}
+	if value, err := perm.FromJSON([]byte(`["exec"]`)); err != nil || value != 0x1 {
+		t.Errorf("FromJSON: expected 0x1, nil; got 0x%x, %v", value, err)
+	}
+
+	expectPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected panic on a frozen type", name)
+			}
+		}()
+		fn()
+	}
+
+	// Copies made before or after Freeze share the frozen state.
+	copied := perm.WithEncodedAs("array")
+	expectPanic("RegisterAlias", func() { _ = perm.RegisterAlias(0, "executable") })
+	expectPanic("RegisterAlias on copy", func() { _ = copied.RegisterAlias(0, "executable") })
+	expectPanic("DeregisterAlias", func() { _ = perm.DeregisterAlias("readable") })
+}
+
+func TestBitfieldType_MakeConstMap(t *testing.T) {
+	m := MakeBitfieldType("Perm", testPermData).MakeConstMap()
+
+	if len(m) != len(testPermData) {
+		t.Errorf("expected %d entries, got %d: %v", len(testPermData), len(m), m)
+	}
+	for index, row := range testPermData {
+		if value, found := m[row.GoName]; !found || value != uint64(1)<<uint(index) {
+			t.Errorf("%s: expected 0x%x, true; got 0x%x, %v", row.GoName, uint64(1)<<uint(index), value, found)
+		}
+	}
+}
+
+func TestBitfieldType_MakeReverseMap(t *testing.T) {
+	m := MakeBitfieldType("Perm", testPermData).MakeReverseMap()
+
+	if len(m) != len(testPermData) {
+		t.Errorf("expected %d entries, got %d: %v", len(testPermData), len(m), m)
+	}
+	for index, row := range testPermData {
+		if name := m[uint64(1)<<uint(index)]; name != row.Name {
+			t.Errorf("bit %d: expected %q, got %q", index, row.Name, name)
+		}
+	}
+}
+
+func TestBitfieldType_TruncateTo(t *testing.T) {
+	perm, err := MakeBitfieldType("Perm", testPermData).TruncateTo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := perm.FromString("exec|write"); err != nil || value != 0x3 {
+		t.Errorf("FromString(exec|write): expected 0x3, nil; got 0x%x, %v", value, err)
+	}
+	if _, err := perm.FromString("read"); !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("FromString(read): expected %v, got %v", ErrInvalidBitfieldName, err)
+	}
+	if _, err := perm.TruncateTo(3); !errors.Is(err, ErrInvalidBitfieldIndex) {
+		t.Errorf("TruncateTo(3): expected %v, got %v", ErrInvalidBitfieldIndex, err)
+	}
+}
+
+func TestBitfieldType_ExtendWith(t *testing.T) {
+	base := MakeBitfieldType("Perm", testPermData)
+	perm, err := base.ExtendWith([]BitfieldData{{Name: "sticky"}, {Name: "setuid"}}, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := perm.FromString("sticky|setuid|read"); err != nil || value != 0x304 {
+		t.Errorf("FromString: expected 0x304, nil; got 0x%x, %v", value, err)
+	}
+
+	type testCase struct {
+		Name       string
+		Extra      []BitfieldData
+		StartIndex uint
+		Err        error
+	}
+	testData := []testCase{
+		{"overlap", []BitfieldData{{Name: "sticky"}}, 1, ErrDuplicateBitfieldIndex},
+		{"name conflict", []BitfieldData{{Name: "WRITE"}}, 8, ErrDuplicateName},
+		{"beyond width", []BitfieldData{{Name: "sticky"}}, 64, ErrInvalidBitfieldIndex},
+	}
+	for _, row := range testData {
+		if _, err := base.ExtendWith(row.Extra, row.StartIndex); !errors.Is(err, row.Err) {
+			t.Errorf("%s: expected %v, got %v", row.Name, row.Err, err)
+		}
+	}
+}
+
+func TestBitfieldType_MustFromJSON(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	if value := perm.MustFromJSON([]byte(`"read|write"`)); value != 0x6 {
+		t.Errorf("MustFromJSON(read|write): expected 0x6, got 0x%x", value)
+	}
+
+	err := recoverError(func() { perm.MustFromJSON([]byte(`null`)) })
+	if _, ok := err.(IsNullError); !ok {
+		t.Errorf("MustFromJSON(null): expected panic with IsNullError, got %#v", err)
+	}
+
+	err = recoverError(func() { perm.MustFromJSON([]byte(`"sticky"`)) })
+	if x, ok := err.(InvalidBitfieldNameError); !ok || x.Name != "sticky" {
+		t.Errorf("MustFromJSON(sticky): expected panic with InvalidBitfieldNameError, got %#v", err)
+	}
+}
+
+func TestBitfieldType_FromStringOrZero(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	type testCase struct {
+		Name   string
+		Input  string
+		OrZero uint64
+		OrMax  uint64
+	}
+
+	testData := [...]testCase{
+		{"valid", "read|x", 0x5, 0x5},
+		{"zero", "0", 0x0, 0x0},
+		{"partially valid", "read|sticky", 0x0, ^uint64(0)},
+		{"invalid", "sticky|setuid", 0x0, ^uint64(0)},
+	}
+
+	for _, row := range testData {
+		if value := perm.FromStringOrZero(row.Input); value != row.OrZero {
+			t.Errorf("%s: FromStringOrZero(%q): expected 0x%x, got 0x%x", row.Name, row.Input, row.OrZero, value)
+		}
+		if value := perm.FromStringOrMax(row.Input); value != row.OrMax {
+			t.Errorf("%s: FromStringOrMax(%q): expected 0x%x, got 0x%x", row.Name, row.Input, row.OrMax, value)
+		}
+	}
+}
+
+func TestBitfieldType_Intersection(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+	other := MakeBitfieldType("Perm", []BitfieldData{
+		{GoName: "PermExec", Name: "exec"},
+		{GoName: "PermWrite", Name: "writable"},
+		{},
+		{GoName: "PermSticky", Name: "sticky"},
+	})
+
+	result := perm.Intersection(other)
+	if !result.ContainsBit(0) || result.Get(0).Name != "exec" {
+		t.Errorf("bit 0: expected exec to remain named, got %+v", result.Get(0))
+	}
+	if result.ContainsBit(1) {
+		t.Errorf("bit 1: expected a renamed bit to become unnamed, got %+v", result.Get(1))
+	}
+	if result.ContainsBit(2) {
+		t.Errorf("bit 2: expected a receiver-only bit to become unnamed, got %+v", result.Get(2))
+	}
+	if result.ContainsBit(3) {
+		t.Errorf("bit 3: expected an other-only bit to stay unnamed, got %+v", result.Get(3))
+	}
+	if _, err := result.FromString("read"); !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("FromString(read): expected %v, got %v", ErrInvalidBitfieldName, err)
+	}
+}
+
+func TestBitfieldType_DumpBits(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	str := perm.DumpBits(0x4 | 0x1 | 0x100)
+	if len(str) != 64 {
+		t.Fatalf("expected length 64, got %d", len(str))
+	}
+	expected := strings.Repeat("_", 55) + "1" + strings.Repeat("_", 5) + "R.E"
+	if str != expected {
+		t.Errorf("expected %q, got %q", expected, str)
+	}
+	for _, index := range []uint{0, 2, 8} {
+		if ch := str[63-index]; ch == '_' || ch == '.' {
+			t.Errorf("bit %d is set, but its character is %q", index, ch)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		if again := perm.DumpBits(0x4 | 0x1 | 0x100); again != str {
+			t.Fatalf("unstable output: %q then %q", str, again)
+		}
+	}
+}
+
+func TestBitfieldType_WithUnknownBitBehavior(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	type testCase struct {
+		Name  string
+		Mode  UnknownBitMode
+		Input string
+		Value uint64
+		Err   error
+	}
+
+	testData := [...]testCase{
+		{"accepted", UnknownBitIsAccepted, "0x8", 0x8, nil},
+		{"accepted/mixed", UnknownBitIsAccepted, "read|0x8", 0xc, nil},
+		{"ignored", UnknownBitIsIgnored, "0x8", 0x0, nil},
+		{"ignored/mixed", UnknownBitIsIgnored, "read|0x8", 0x4, nil},
+		{"error", UnknownBitIsError, "0x8", 0x0, ErrInvalidBitfieldName},
+		{"error/mixed", UnknownBitIsError, "read|0x8", 0x0, ErrInvalidBitfieldName},
+		{"error/known", UnknownBitIsError, "0x4", 0x4, nil},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			perm := perm.WithUnknownBitBehavior(row.Mode)
+			value, err := perm.FromString(row.Input)
+			if !errors.Is(err, row.Err) || value != row.Value {
+				t.Errorf("expected 0x%x, %v; got 0x%x, %v", row.Value, row.Err, value, err)
+			}
+		})
+	}
+}
+
+func TestBitfieldType_ContainsBit(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	for index := uint(0); index < 3; index++ {
+		if !perm.ContainsBit(index) {
+			t.Errorf("ContainsBit(%d): expected true, got false", index)
+		}
+	}
+	for _, index := range []uint{3, 31, 63, 64, ^uint(0)} {
+		if perm.ContainsBit(index) {
+			t.Errorf("ContainsBit(%d): expected false, got true", index)
+		}
+	}
+
+	perm8 := MakeBitfieldType8("Perm", testPermData)
+	if !perm8.ContainsBit(2) || perm8.ContainsBit(8) {
+		t.Errorf("BitfieldType8.ContainsBit: expected true for 2 and false for 8")
+	}
+}
+
+func TestBitfieldType_String(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	for _, value := range []uint64{0x0, 0x1, 0x5, 0x7, 0x8, 0xf, ^uint64(0)} {
+		if a, b := perm.String(value), perm.ToString(value); a != b {
+			t.Errorf("String(0x%x): expected %q (ToString), got %q", value, b, a)
+		}
+		if a, b := perm.GoString(value), perm.ToGoString(value); a != b {
+			t.Errorf("GoString(0x%x): expected %q (ToGoString), got %q", value, b, a)
+		}
+	}
+}
+
+func TestBitfieldType_MakePredicate(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+	canReadWrite := perm.MakePredicate(0x6)
+	canReadOrWrite := perm.MakeAnyPredicate(0x6)
+
+	type testCase struct {
+		Value  uint64
+		ExpAll bool
+		ExpAny bool
+	}
+
+	testData := [...]testCase{
+		{0x0, false, false},
+		{0x1, false, false},
+		{0x2, false, true},
+		{0x4, false, true},
+		{0x5, false, true},
+		{0x6, true, true},
+		{0x7, true, true},
+		{0xf, true, true},
+	}
+
+	for _, row := range testData {
+		if got := canReadWrite(row.Value); got != row.ExpAll {
+			t.Errorf("MakePredicate(0x6)(0x%x): expected %v, got %v", row.Value, row.ExpAll, got)
+		}
+		if got := canReadOrWrite(row.Value); got != row.ExpAny {
+			t.Errorf("MakeAnyPredicate(0x6)(0x%x): expected %v, got %v", row.Value, row.ExpAny, got)
+		}
+	}
+}
+
+func TestBitfieldType_HasAll(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	type testCase struct {
+		Value  uint64
+		Mask   uint64
+		ExpAll bool
+		ExpAny bool
+	}
+
+	testData := [...]testCase{
+		{0x0, 0x0, false, false},
+		{0x7, 0x0, false, false},
+		{0x0, 0x6, false, false},
+		{0x2, 0x6, false, true},
+		{0x6, 0x6, true, true},
+		{0x7, 0x6, true, true},
+		{0x1, 0x1, true, true},
+		{0x1, 0x6, false, false},
+	}
+
+	for _, row := range testData {
+		if got := perm.HasAll(row.Value, row.Mask); got != row.ExpAll {
+			t.Errorf("HasAll(0x%x, 0x%x): expected %v, got %v", row.Value, row.Mask, row.ExpAll, got)
+		}
+		if got := perm.HasAny(row.Value, row.Mask); got != row.ExpAny {
+			t.Errorf("HasAny(0x%x, 0x%x): expected %v, got %v", row.Value, row.Mask, row.ExpAny, got)
+		}
+	}
+}
+
+func TestBitfieldType_SetClearToggle(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	if got := perm.Set(0x1, 0x4); got != 0x5 {
+		t.Errorf("Set(0x1, 0x4): expected 0x5, got 0x%x", got)
+	}
+	if got := perm.Set(0x5, 0x4); got != 0x5 {
+		t.Errorf("Set(0x5, 0x4): expected 0x5, got 0x%x", got)
+	}
+	if got := perm.Clear(0x7, 0x2); got != 0x5 {
+		t.Errorf("Clear(0x7, 0x2): expected 0x5, got 0x%x", got)
+	}
+	if got := perm.Clear(0x5, 0x2); got != 0x5 {
+		t.Errorf("Clear(0x5, 0x2): expected 0x5, got 0x%x", got)
+	}
+	if got := perm.Toggle(0x5, 0x2); got != 0x7 {
+		t.Errorf("Toggle(0x5, 0x2): expected 0x7, got 0x%x", got)
+	}
+	if got := perm.Toggle(0x7, 0x2); got != 0x5 {
+		t.Errorf("Toggle(0x7, 0x2): expected 0x5, got 0x%x", got)
+	}
+
+	ops := map[string]func(value, bit uint64) uint64{
+		"Set":    perm.Set,
+		"Clear":  perm.Clear,
+		"Toggle": perm.Toggle,
+	}
+	for name, op := range ops {
+		for _, bit := range []uint64{0x0, 0x6, 0x8} {
+			err := recoverError(func() { op(0x0, bit) })
+			if !errors.Is(err, ErrInvalidBitfieldIndex) {
+				t.Errorf("%s(0x0, 0x%x): expected panic with %v, got %v", name, bit, ErrInvalidBitfieldIndex, err)
+			}
+		}
+	}
+}
+
+func TestBitfieldType_DescribeBit(t *testing.T) {
+	perm := MakeBitfieldType("Perm", []BitfieldData{
+		{GoName: "PermExec", Name: "exec", Description: "may execute"},
+		{GoName: "PermWrite", Name: "write"},
+		{GoName: "PermRead"},
+	})
+
+	type testCase struct {
+		Index  uint
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{0, "may execute"},
+		{1, "write"},
+		{2, "PermRead"},
+		{3, ""},
+		{64, ""},
+	}
+
+	for _, row := range testData {
+		if got := perm.DescribeBit(row.Index); got != row.Expect {
+			t.Errorf("DescribeBit(%d): expected %q, got %q", row.Index, row.Expect, got)
+		}
+	}
+}
+
+func TestBitfieldType_MustFromString(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	if value := perm.MustFromString("r|w"); value != 0x6 {
+		t.Errorf("MustFromString(\"r|w\"): expected 0x6, got 0x%x", value)
+	}
+
+	err := recoverError(func() { perm.MustFromString("read|delete") })
+	if !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Fatalf("MustFromString(\"read|delete\"): expected panic with %v, got %v", ErrInvalidBitfieldName, err)
+	}
+	msg := err.Error()
+	for _, want := range []string{"Perm", `"delete"`, `"exec"`, `"write"`, `"read"`} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("MustFromString(\"read|delete\"): expected panic message to contain %s, got %q", want, msg)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package enumhelper
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBitfieldType16(t *testing.T) {
+	in := make([]BitfieldData, 16+1)
+	copy(in, testPermData)
+	in[15] = BitfieldData{GoName: "PermTop", Name: "top"}
+	in[16] = BitfieldData{GoName: "PermBeyond", Name: "beyond"}
+	perm := MakeBitfieldType16("Perm", in)
+
+	if perm.ContainsBit(16) {
+		t.Errorf("ContainsBit(16): expected false")
+	}
+	if _, found := perm.ByName["beyond"]; found {
+		t.Errorf("ByName: expected no entry for a bit beyond bit 15")
+	}
+	if _, err := perm.FromString("beyond"); !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("FromString(beyond): expected %v, got %v", ErrInvalidBitfieldName, err)
+	}
+	if _, err := perm.FromString("0x10000"); !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("FromString beyond bit 15: expected %v, got %v", ErrInvalidBitfieldName, err)
+	}
+
+	var u16 uint16
+	if err := perm.ScanBitfield(int64(0x10000), &u16); !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("ScanBitfield beyond bit 15: expected %v, got %v", ErrInvalidBitfieldName, err)
+	}
+
+	if value, err := perm.FromString("top|r"); err != nil || value != 0x8000|0x4 {
+		t.Errorf("FromString(top|r): expected 0x%x, nil; got 0x%x, %v", 0x8000|0x4, value, err)
+	}
+	raw, err := perm.ToJSON(0x8000 | 0x1)
+	if err != nil {
+		t.Fatalf("ToJSON: unexpected error: %v", err)
+	}
+	if value, err := perm.FromJSON(raw); err != nil || value != 0x8000|0x1 {
+		t.Errorf("FromJSON(%s): expected 0x%x, nil; got 0x%x, %v", raw, 0x8000|0x1, value, err)
+	}
+	if str := perm.DumpBits(0); len(str) != 16 {
+		t.Errorf("DumpBits: expected length 16, got %d", len(str))
+	}
+}
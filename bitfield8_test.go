@@ -0,0 +1,55 @@
+package enumhelper
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestBitfieldType8(t *testing.T) {
+	perm := MakeBitfieldType8("Perm", testPermData).WithValidationHook(func(value uint8) error {
+		return rejectReadWrite(uint64(value))
+	})
+
+	if value, err := perm.FromString("r|x"); err != nil || value != 0x5 {
+		t.Errorf("FromString: expected 0x5, nil; got 0x%x, %v", value, err)
+	}
+	if _, err := perm.FromString("read|write"); !errors.Is(err, errReadWrite) {
+		t.Errorf("FromString: expected %v, got %v", errReadWrite, err)
+	}
+	if _, err := perm.FromString("0x100"); !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("FromString beyond bit 7: expected %v, got %v", ErrInvalidBitfieldName, err)
+	}
+
+	var u8 uint8
+	if err := perm.ScanBitfield(int64(0x100), &u8); !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("ScanBitfield beyond bit 7: expected %v, got %v", ErrInvalidBitfieldName, err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(perm.NewFlag(&u8), "p", perm.FlagUsage())
+	if err := fs.Parse([]string{"-p=read", "-p=exec"}); err != nil || u8 != 0x5 {
+		t.Errorf("flag: expected 0x5, nil; got 0x%x, %v", u8, err)
+	}
+
+	var names []string
+	for _, data := range perm.SetBits(0x7) {
+		names = append(names, data.Name)
+	}
+	if len(names) != 3 || names[0] != "exec" || names[2] != "read" {
+		t.Errorf("SetBits: expected [exec write read], got %q", names)
+	}
+
+	// ByName is a snapshot, but parsing sees aliases registered later.
+	if err := perm.Widen().RegisterAlias(2, "readable"); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := perm.ByName["readable"]; found {
+		t.Errorf("ByName: expected no entry for an alias registered after construction")
+	}
+	if value, err := perm.FromString("readable"); err != nil || value != 0x4 {
+		t.Errorf("FromString(alias): expected 0x4, nil; got 0x%x, %v", value, err)
+	}
+}
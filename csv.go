@@ -0,0 +1,76 @@
+package enumhelper
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// CSVHeader returns the column headers for the records produced by
+// MarshalCSVRecord.
+func (enum EnumType) CSVHeader() []string {
+	return []string{"value", "name", "go_name"}
+}
+
+// MarshalCSVRecord returns a CSV record for the given enum value, with the
+// columns listed by CSVHeader: the numeric value, the Name, and the GoName.
+// The name columns are empty if the enum value is out of range.
+func (enum EnumType) MarshalCSVRecord(value uint) []string {
+	var name, goName string
+	if enum.Contains(value) {
+		row := enum.Data[value]
+		name, goName = row.Name, row.GoName
+	}
+	return []string{strconv.FormatUint(uint64(value), 10), name, goName}
+}
+
+// UnmarshalCSVRecord parses a CSV record holding an enum value.  The record
+// may hold a single name, or the three columns produced by MarshalCSVRecord.
+// In the latter case, empty columns are ignored and the remaining columns
+// must all refer to the same enum value.  Returns InvalidEnumNameError or
+// InvalidEnumValueError if a column cannot be parsed.
+func (enum EnumType) UnmarshalCSVRecord(record []string) (uint, error) {
+	switch len(record) {
+	case 1:
+		return enum.FromString(record[0])
+	case 3:
+		// pass
+	default:
+		return 0, fmt.Errorf("cannot unmarshal CSV record with %d fields into %s; expected 1 or 3", len(record), enum.Type)
+	}
+
+	var value uint
+	found := false
+	if str := record[0]; str != "" {
+		u64, err := strconv.ParseUint(str, 10, 0)
+		if err != nil {
+			return 0, fmt.Errorf("cannot unmarshal CSV value %q into %s: %w", str, enum.Type, err)
+		}
+		if !enum.Contains(uint(u64)) {
+			return 0, InvalidEnumValueError{
+				Type:  enum.Type,
+				Value: uint(u64),
+				Limit: enum.Len(),
+			}
+		}
+		value, found = uint(u64), true
+	}
+
+	for _, str := range record[1:] {
+		if str == "" {
+			continue
+		}
+		u, err := enum.FromString(str)
+		if err != nil {
+			return 0, err
+		}
+		if found && u != value {
+			return 0, fmt.Errorf("conflicting CSV fields for %s: %q is %d, not %d", enum.Type, str, u, value)
+		}
+		value, found = u, true
+	}
+
+	if !found {
+		return 0, fmt.Errorf("cannot unmarshal empty CSV record into %s", enum.Type)
+	}
+	return enum.validate(value)
+}
@@ -0,0 +1,55 @@
+package enumhelper
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEnumType_UnmarshalCSVRecord(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	type testCase struct {
+		Name   string
+		Record []string
+		Value  uint
+		Err    error
+	}
+
+	testData := [...]testCase{
+		{"one/name", []string{"blue"}, 2, nil},
+		{"one/alias", []string{"azure"}, 2, nil},
+		{"one/invalid", []string{"chartreuse"}, 0, ErrInvalidEnumName},
+		{"three/full", []string{"1", "green", "ColorGreen"}, 1, nil},
+		{"three/value-only", []string{"3", "", ""}, 3, nil},
+		{"three/name-only", []string{"", "red", ""}, 0, nil},
+		{"three/out-of-range", []string{"4", "", ""}, 0, ErrInvalidEnumValue},
+		{"three/invalid-name", []string{"", "chartreuse", ""}, 0, ErrInvalidEnumName},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			value, err := color.UnmarshalCSVRecord(row.Record)
+			if !errors.Is(err, row.Err) || value != row.Value {
+				t.Errorf("expected %d, %v; got %d, %v", row.Value, row.Err, value, err)
+			}
+		})
+	}
+
+	for _, value := range []uint{0, 1, 2, 3} {
+		record := color.MarshalCSVRecord(value)
+		if got, err := color.UnmarshalCSVRecord(record); err != nil || got != value {
+			t.Errorf("round trip %v: expected %d, nil; got %d, %v", record, value, got, err)
+		}
+	}
+
+	for _, record := range [][]string{
+		{"1", "red", ""},
+		{"", "red", "ColorGreen"},
+		{"", "", ""},
+		{"red", "green"},
+	} {
+		if value, err := color.UnmarshalCSVRecord(record); err == nil {
+			t.Errorf("%v: expected error, got %d", record, value)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package enumhelper
+
+import (
+	"strings"
+)
+
+// MarshalCUE returns a CUE definition for this enum type, bound to the given
+// CUE field name.  The definition is a disjunction of the canonical names.
+func (enum EnumType) MarshalCUE(typeName string) string {
+	var buf strings.Builder
+	buf.WriteString(typeName)
+	buf.WriteString(":")
+	sep := " "
+	enum.ForEach(func(data AnnotatedEnumData) {
+		name := data.canonicalName()
+		if name == "" {
+			return
+		}
+		buf.WriteString(sep)
+		buf.WriteString(jsonQuote(name))
+		sep = " | "
+	})
+	buf.WriteString("\n")
+	return buf.String()
+}
+
+// MarshalCUE returns a CUE definition for this bitfield type, bound to the
+// given CUE field name.  The definition is a closed struct with one optional
+// bool field per named bit.
+func (bitfield BitfieldType) MarshalCUE(typeName string) string {
+	var buf strings.Builder
+	buf.WriteString(typeName)
+	buf.WriteString(": close({\n")
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		name := data.canonicalName()
+		if name == "" {
+			return
+		}
+		buf.WriteString("\t")
+		buf.WriteString(jsonQuote(name))
+		buf.WriteString("?: bool\n")
+	})
+	buf.WriteString("})\n")
+	return buf.String()
+}
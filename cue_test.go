@@ -0,0 +1,40 @@
+package enumhelper
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestEnumType_MarshalCUE(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	actual := color.MarshalCUE("#Color")
+	expected := `#Color: "red" | "green" | "blue" | "purple"` + "\n"
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestBitfieldType_MarshalCUE(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	actual := perm.MarshalCUE("#Perm")
+	if !strings.HasPrefix(actual, "#Perm: close({\n") || !strings.HasSuffix(actual, "})\n") {
+		t.Fatalf("expected a close({...}) definition, got %q", actual)
+	}
+
+	fieldRE := regexp.MustCompile(`^\t"[^"]+"\?: bool$`)
+	lines := strings.Split(strings.TrimSuffix(actual, "\n"), "\n")
+	for _, line := range lines[1 : len(lines)-1] {
+		if !fieldRE.MatchString(line) {
+			t.Errorf("malformed field line %q", line)
+		}
+	}
+
+	for _, name := range []string{"exec", "write", "read"} {
+		if !strings.Contains(actual, `"`+name+`"?: bool`) {
+			t.Errorf("missing field for %q in %q", name, actual)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package enumhelper
+
+import (
+	"bytes"
+	"strings"
+)
+
+// BitfieldTypeDiff describes the differences between two bitfield types.  It
+// is returned by BitfieldType.Diff.
+type BitfieldTypeDiff struct {
+	// Added lists the bits which are named in the new type but unnamed in
+	// the old type.
+	Added []AnnotatedBitfieldData
+
+	// Removed lists the bits which are named in the old type but unnamed in
+	// the new type.
+	Removed []AnnotatedBitfieldData
+
+	// Changed lists the bits which are named in both types, but whose data
+	// differs, e.g. because the bit was renamed.
+	Changed []BitfieldChange
+}
+
+// BitfieldChange describes a bit whose data differs between two bitfield
+// types.
+type BitfieldChange struct {
+	// Index is the index of the bit.
+	Index uint
+
+	// Old is the data for the bit in the old type.
+	Old BitfieldData
+
+	// New is the data for the bit in the new type.
+	New BitfieldData
+}
+
+// Diff compares this bitfield type (the old type) with other (the new type)
+// and returns the differences between their bits.
+func (bitfield BitfieldType) Diff(other BitfieldType) BitfieldTypeDiff {
+	var diff BitfieldTypeDiff
+	width := bitfield.width()
+	if other.width() > width {
+		width = other.width()
+	}
+	for index := uint(0); index < width; index++ {
+		oldData := AnnotatedBitfieldData{
+			BitfieldData: bitfield.dataAt(index),
+			Index:        index,
+			Bit:          (1 << index),
+		}
+		newData := AnnotatedBitfieldData{
+			BitfieldData: other.dataAt(index),
+			Index:        index,
+			Bit:          (1 << index),
+		}
+		oldNamed := (oldData.GoName != "" || oldData.Name != "")
+		newNamed := (newData.GoName != "" || newData.Name != "")
+		switch {
+		case oldNamed && !newNamed:
+			diff.Removed = append(diff.Removed, oldData)
+		case !oldNamed && newNamed:
+			diff.Added = append(diff.Added, newData)
+		case oldNamed && !equalBitfieldData(oldData.BitfieldData, newData.BitfieldData):
+			diff.Changed = append(diff.Changed, BitfieldChange{
+				Index: index,
+				Old:   oldData.BitfieldData,
+				New:   newData.BitfieldData,
+			})
+		}
+	}
+	return diff
+}
+
+// EnumTypeDiff describes the differences between two enum types.  It is
+// returned by EnumType.Diff.
+type EnumTypeDiff struct {
+	// Added lists the values which exist in the new type but not in the old
+	// type.
+	Added []AnnotatedEnumData
+
+	// Removed lists the values which exist in the old type but not in the
+	// new type.
+	Removed []AnnotatedEnumData
+
+	// Changed lists the values which exist in both types, but whose data
+	// differs, e.g. because the value was renamed.
+	Changed []EnumChange
+}
+
+// EnumChange describes an enum value whose data differs between two enum
+// types.
+type EnumChange struct {
+	// Value is the numeric value.
+	Value uint
+
+	// Old is the data for the value in the old type.
+	Old EnumData
+
+	// New is the data for the value in the new type.
+	New EnumData
+}
+
+// Diff compares this enum type (the old type) with other (the new type) and
+// returns the differences between their values.
+func (enum EnumType) Diff(other EnumType) EnumTypeDiff {
+	var diff EnumTypeDiff
+	oldLen := enum.Len()
+	newLen := other.Len()
+	for value := uint(0); value < oldLen || value < newLen; value++ {
+		switch {
+		case value >= newLen:
+			diff.Removed = append(diff.Removed, *enum.Data[value])
+		case value >= oldLen:
+			diff.Added = append(diff.Added, *other.Data[value])
+		case !equalEnumData(enum.Data[value].EnumData, other.Data[value].EnumData):
+			diff.Changed = append(diff.Changed, EnumChange{
+				Value: value,
+				Old:   enum.Data[value].EnumData,
+				New:   other.Data[value].EnumData,
+			})
+		}
+	}
+	return diff
+}
+
+func equalEnumData(a, b EnumData) bool {
+	return a.GoName == b.GoName && a.Name == b.Name && bytes.Equal(a.JSON, b.JSON) && equalStrings(a.Aliases, b.Aliases) && a.Description == b.Description && a.Deprecated == b.Deprecated
+}
+
+func equalBitfieldData(a, b BitfieldData) bool {
+	return a.GoName == b.GoName && a.Name == b.Name && a.Description == b.Description && equalStrings(a.Aliases, b.Aliases)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyDiff returns a copy of this bitfield type with the given diff applied.
+// Applying a.Diff(b) to a yields a type with the same bits as b.
+//
+// Returns InvalidBitfieldIndexError if a bit index is out of range,
+// StaleDiffError if a removed or changed bit does not match this type's
+// current data for that bit, DuplicateBitfieldIndexError if an added bit would
+// replace an existing named bit, or DuplicateNameError if an added or changed
+// bit has a name that conflicts with another bit.
+func (bitfield BitfieldType) ApplyDiff(diff BitfieldTypeDiff) (BitfieldType, error) {
+	in := bitfield.rawData()
+	touched := make(map[uint]struct{}, len(diff.Added)+len(diff.Changed))
+
+	checkIndex := func(index uint) error {
+		if width := bitfield.width(); index >= width {
+			return InvalidBitfieldIndexError{
+				Type:  bitfield.Type,
+				Index: index,
+				Limit: width,
+			}
+		}
+		return nil
+	}
+
+	for _, data := range diff.Removed {
+		if err := checkIndex(data.Index); err != nil {
+			return BitfieldType{}, err
+		}
+		if !equalBitfieldData(in[data.Index], data.BitfieldData) {
+			return BitfieldType{}, StaleDiffError{
+				Type:  bitfield.Type,
+				Index: data.Index,
+			}
+		}
+		in[data.Index] = BitfieldData{}
+	}
+
+	for _, change := range diff.Changed {
+		if err := checkIndex(change.Index); err != nil {
+			return BitfieldType{}, err
+		}
+		if !equalBitfieldData(in[change.Index], change.Old) {
+			return BitfieldType{}, StaleDiffError{
+				Type:  bitfield.Type,
+				Index: change.Index,
+			}
+		}
+		in[change.Index] = change.New
+		touched[change.Index] = struct{}{}
+	}
+
+	for _, data := range diff.Added {
+		if err := checkIndex(data.Index); err != nil {
+			return BitfieldType{}, err
+		}
+		if in[data.Index].GoName != "" || in[data.Index].Name != "" {
+			return BitfieldType{}, DuplicateBitfieldIndexError{
+				Type:  bitfield.Type,
+				Index: data.Index,
+			}
+		}
+		in[data.Index] = data.BitfieldData
+		touched[data.Index] = struct{}{}
+	}
+
+	owners := make(map[string]uint, 4*len(in))
+	for index := range in {
+		if _, found := touched[uint(index)]; found {
+			continue
+		}
+		for _, name := range in[index].allNames() {
+			owners[strings.ToLower(name)] = uint(index)
+		}
+	}
+	for index := uint(0); index < uint(len(in)); index++ {
+		if _, found := touched[index]; !found {
+			continue
+		}
+		names := in[index].allNames()
+		for _, name := range names {
+			if owner, found := owners[strings.ToLower(name)]; found && owner != index {
+				return BitfieldType{}, DuplicateNameError{
+					Type: bitfield.Type,
+					Name: name,
+				}
+			}
+		}
+		for _, name := range names {
+			owners[strings.ToLower(name)] = index
+		}
+	}
+
+	return bitfield.rebuild(in), nil
+}
+
+// ApplyDiff returns a copy of this enum type with the given diff applied,
+// carrying over the options configured on this enum type.  Applying
+// a.Diff(b) to a yields a type with the same values as b.
+//
+// Because enum values are contiguous, removed values must form a suffix of
+// this type's values, and added values must continue numbering from the new
+// end.  Returns InvalidEnumValueError if a removed or changed value is out of
+// range, StaleDiffError if a removed or changed value does not match this
+// type's current data for that value or if the diff would leave a gap, or
+// DuplicateNameError if two values in the result share a name.
+func (enum EnumType) ApplyDiff(diff EnumTypeDiff) (EnumType, error) {
+	in := enum.rawData()
+
+	checkValue := func(value uint, data EnumData) error {
+		if limit := uint(len(in)); value >= limit {
+			return InvalidEnumValueError{
+				Type:  enum.Type,
+				Value: value,
+				Limit: limit,
+			}
+		}
+		if !equalEnumData(in[value], data) {
+			return StaleDiffError{
+				Type:  enum.Type,
+				Index: value,
+			}
+		}
+		return nil
+	}
+
+	for _, change := range diff.Changed {
+		if err := checkValue(change.Value, change.Old); err != nil {
+			return EnumType{}, err
+		}
+		in[change.Value] = change.New
+	}
+
+	removed := make(map[uint]struct{}, len(diff.Removed))
+	newLen := uint(len(in))
+	for _, data := range diff.Removed {
+		if err := checkValue(data.Value, data.EnumData); err != nil {
+			return EnumType{}, err
+		}
+		removed[data.Value] = struct{}{}
+		if data.Value < newLen {
+			newLen = data.Value
+		}
+	}
+	for value := newLen; value < uint(len(in)); value++ {
+		if _, found := removed[value]; !found {
+			return EnumType{}, StaleDiffError{
+				Type:  enum.Type,
+				Index: value,
+			}
+		}
+	}
+	in = in[:newLen]
+
+	added := make(map[uint]EnumData, len(diff.Added))
+	for _, data := range diff.Added {
+		added[data.Value] = data.EnumData
+	}
+	for range diff.Added {
+		value := uint(len(in))
+		data, found := added[value]
+		if !found {
+			return EnumType{}, StaleDiffError{
+				Type:  enum.Type,
+				Index: value,
+			}
+		}
+		in = append(in, data)
+	}
+
+	seen := make(map[string]struct{}, 4*len(in))
+	for _, data := range in {
+		names := make([]string, 0, 2+len(data.Aliases))
+		names = append(names, data.Name, data.GoName)
+		names = append(names, data.Aliases...)
+		for _, name := range names {
+			if name == "" {
+				continue
+			}
+			if _, found := seen[strings.ToLower(name)]; found {
+				return EnumType{}, DuplicateNameError{
+					Type: enum.Type,
+					Name: name,
+				}
+			}
+		}
+		for _, name := range names {
+			if name != "" {
+				seen[strings.ToLower(name)] = struct{}{}
+			}
+		}
+	}
+
+	return enum.rebuild(in), nil
+}
@@ -0,0 +1,151 @@
+package enumhelper
+
+import (
+	"testing"
+)
+
+func TestEnumType_Diff(t *testing.T) {
+	oldType := MakeEnumType("Color", testColorData)
+	newType := MakeEnumType("Color", []EnumData{
+		{GoName: "ColorRed", Name: "red"},
+		{GoName: "ColorGreen", Name: "verde"},
+		{GoName: "ColorBlue", Name: "blue", Aliases: []string{"azure"}},
+	})
+
+	diff := oldType.Diff(newType)
+	if len(diff.Added) != 0 {
+		t.Errorf("Added: expected none, got %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Value != 3 || diff.Removed[0].Name != "purple" {
+		t.Errorf("Removed: expected [purple], got %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Value != 1 || diff.Changed[0].Old.Name != "green" || diff.Changed[0].New.Name != "verde" {
+		t.Errorf("Changed: expected [green -> verde], got %v", diff.Changed)
+	}
+
+	diff = newType.Diff(oldType)
+	if len(diff.Added) != 1 || diff.Added[0].Value != 3 || diff.Added[0].Name != "purple" {
+		t.Errorf("reverse Added: expected [purple], got %v", diff.Added)
+	}
+	if len(diff.Removed) != 0 {
+		t.Errorf("reverse Removed: expected none, got %v", diff.Removed)
+	}
+
+	diff = oldType.Diff(oldType)
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Changed) != 0 {
+		t.Errorf("self Diff: expected empty diff, got %+v", diff)
+	}
+}
+
+func TestBitfieldType_Diff(t *testing.T) {
+	oldType := MakeBitfieldType("Perm", testPermData)
+	newType := MakeBitfieldType("Perm", []BitfieldData{
+		{GoName: "PermExec", Name: "execute", Aliases: []string{"x"}},
+		{},
+		{GoName: "PermRead", Name: "read", Aliases: []string{"r"}},
+		{GoName: "PermSticky", Name: "sticky"},
+	})
+
+	diff := oldType.Diff(newType)
+	if len(diff.Added) != 1 || diff.Added[0].Index != 3 || diff.Added[0].Name != "sticky" {
+		t.Errorf("Added: expected [sticky], got %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Index != 1 || diff.Removed[0].Name != "write" {
+		t.Errorf("Removed: expected [write], got %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Index != 0 || diff.Changed[0].Old.Name != "exec" || diff.Changed[0].New.Name != "execute" {
+		t.Errorf("Changed: expected [exec -> execute], got %v", diff.Changed)
+	}
+
+	diff = oldType.Diff(oldType)
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Changed) != 0 {
+		t.Errorf("self Diff: expected empty diff, got %+v", diff)
+	}
+}
+
+func TestEnumType_ApplyDiff(t *testing.T) {
+	a := MakeEnumType("Color", testColorData)
+	b := MakeEnumType("Color", []EnumData{
+		{GoName: "ColorRed", Name: "red"},
+		{GoName: "ColorGreen", Name: "verde"},
+	})
+	c := MakeEnumType("Color", []EnumData{
+		{GoName: "ColorRed", Name: "rojo"},
+		{GoName: "ColorGreen", Name: "green"},
+		{GoName: "ColorBlue", Name: "blue", Aliases: []string{"azure"}},
+		{GoName: "ColorPurple", Name: "purple"},
+		{GoName: "ColorOrange", Name: "orange"},
+		{GoName: "ColorYellow", Name: "yellow"},
+	})
+
+	for _, pair := range [][2]EnumType{{a, b}, {b, a}, {a, c}, {c, b}, {a, a}} {
+		from, to := pair[0], pair[1]
+		result, err := from.ApplyDiff(from.Diff(to))
+		if err != nil {
+			t.Errorf("ApplyDiff(%v -> %v): unexpected error: %v", from.Names, to.Names, err)
+			continue
+		}
+		if diff := result.Diff(to); len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Changed) != 0 {
+			t.Errorf("ApplyDiff(%v -> %v): result differs from target: %+v", from.Names, to.Names, diff)
+		}
+	}
+
+	// A diff computed against a is stale once a has been changed.
+	_, err := c.ApplyDiff(a.Diff(b))
+	if _, ok := err.(StaleDiffError); !ok {
+		t.Errorf("ApplyDiff(stale): expected StaleDiffError, got %#v", err)
+	}
+
+	// Removing a value from the middle would leave a gap.
+	diff := EnumTypeDiff{Removed: []AnnotatedEnumData{*a.Data[1]}}
+	_, err = a.ApplyDiff(diff)
+	if x, ok := err.(StaleDiffError); !ok || x.Index != 2 {
+		t.Errorf("ApplyDiff(gap): expected StaleDiffError at index 2, got %#v", err)
+	}
+
+	diff = EnumTypeDiff{Added: []AnnotatedEnumData{{EnumData: EnumData{GoName: "ColorCyan", Name: "azure"}, Value: 4}}}
+	_, err = a.ApplyDiff(diff)
+	if _, ok := err.(DuplicateNameError); !ok {
+		t.Errorf("ApplyDiff(duplicate): expected DuplicateNameError, got %#v", err)
+	}
+
+	diff = EnumTypeDiff{Changed: []EnumChange{{Value: 9}}}
+	_, err = a.ApplyDiff(diff)
+	if _, ok := err.(InvalidEnumValueError); !ok {
+		t.Errorf("ApplyDiff(out of range): expected InvalidEnumValueError, got %#v", err)
+	}
+}
+
+func TestBitfieldType_ApplyDiff(t *testing.T) {
+	a := MakeBitfieldType("Perm", testPermData)
+	b := MakeBitfieldType("Perm", []BitfieldData{
+		{GoName: "PermExec", Name: "execute", Aliases: []string{"x"}},
+		{},
+		{GoName: "PermRead", Name: "read", Aliases: []string{"r"}},
+		{GoName: "PermSticky", Name: "sticky"},
+	})
+
+	for _, pair := range [][2]BitfieldType{{a, b}, {b, a}, {a, a}} {
+		from, to := pair[0], pair[1]
+		result, err := from.ApplyDiff(from.Diff(to))
+		if err != nil {
+			t.Errorf("ApplyDiff: unexpected error: %v", err)
+			continue
+		}
+		if diff := result.Diff(to); len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Changed) != 0 {
+			t.Errorf("ApplyDiff: result differs from target: %+v", diff)
+		}
+	}
+
+	// A diff computed against a is stale once a has been changed.
+	_, err := b.ApplyDiff(a.Diff(b))
+	if _, ok := err.(StaleDiffError); !ok {
+		t.Errorf("ApplyDiff(stale): expected StaleDiffError, got %#v", err)
+	}
+
+	diff := BitfieldTypeDiff{Removed: []AnnotatedBitfieldData{{BitfieldData: BitfieldData{GoName: "PermWrite", Name: "writable"}, Index: 1, Bit: 0x2}}}
+	_, err = a.ApplyDiff(diff)
+	if x, ok := err.(StaleDiffError); !ok || x.Index != 1 {
+		t.Errorf("ApplyDiff(stale removal): expected StaleDiffError at index 1, got %#v", err)
+	}
+}
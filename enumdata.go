@@ -4,7 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 // EnumData holds data about one particular enum value.
@@ -24,6 +29,27 @@ type EnumData struct {
 	//
 	// Optional.
 	Aliases []string
+
+	// Description is a human-readable description of this enum value, for
+	// use in documentation and help text.
+	//
+	// Optional.
+	Description string
+
+	// Deprecated marks this enum value as retained only for backward
+	// compatibility.  Deprecated values can still be parsed, but they are
+	// omitted from the list of allowed names in InvalidEnumNameError.
+	//
+	// Optional.
+	Deprecated bool
+}
+
+// canonicalName returns Name, or GoName if Name is empty.
+func (data EnumData) canonicalName() string {
+	if data.Name != "" {
+		return data.Name
+	}
+	return data.GoName
 }
 
 // MakeAllowedEnumNames returns the list of canonical string representations
@@ -36,6 +62,18 @@ func MakeAllowedEnumNames(enumData []EnumData) []string {
 	return out
 }
 
+// MakeActiveEnumNames is like MakeAllowedEnumNames, but it omits deprecated
+// values.  It is used for the list of allowed names in InvalidEnumNameError.
+func MakeActiveEnumNames(enumData []EnumData) []string {
+	out := make([]string, 0, len(enumData))
+	for _, row := range enumData {
+		if !row.Deprecated {
+			out = append(out, row.Name)
+		}
+	}
+	return out
+}
+
 // DereferenceEnumData returns enumData[value] or panics with InvalidEnumValueError.
 func DereferenceEnumData(enumName string, enumData []EnumData, value uint) EnumData {
 	if limit := uint(len(enumData)); value >= limit {
@@ -50,8 +88,14 @@ func DereferenceEnumData(enumName string, enumData []EnumData, value uint) EnumD
 
 // MarshalEnumToJSON marshals this enum value to JSON.  It may panic with
 // InvalidEnumValueError if the enum value is out of range.
+//
+// MarshalEnumToJSON shares its implementation with EnumType.ToJSON.
 func MarshalEnumToJSON(enumName string, enumData []EnumData, value uint) ([]byte, error) {
-	row := DereferenceEnumData(enumName, enumData, value)
+	return marshalEnumData(DereferenceEnumData(enumName, enumData, value))
+}
+
+// marshalEnumData marshals the enum value described by row to JSON.
+func marshalEnumData(row EnumData) ([]byte, error) {
 	if row.JSON == nil {
 		return json.Marshal(row.Name)
 	}
@@ -60,6 +104,9 @@ func MarshalEnumToJSON(enumName string, enumData []EnumData, value uint) ([]byte
 
 // ParseEnum parses an enum value.  Returns InvalidEnumNameError if the string
 // cannot be parsed.
+//
+// ParseEnum scans enumData on every call; callers which parse repeatedly
+// should use MakeEnumType and EnumType.FromString instead.
 func ParseEnum(enumName string, enumData []EnumData, str string) (uint, error) {
 	for index, row := range enumData {
 		if strings.EqualFold(str, row.Name) || strings.EqualFold(str, row.GoName) {
@@ -75,14 +122,84 @@ func ParseEnum(enumName string, enumData []EnumData, str string) (uint, error) {
 	return 0, InvalidEnumNameError{
 		Type:    enumName,
 		Name:    str,
-		Allowed: MakeAllowedEnumNames(enumData),
+		Allowed: MakeActiveEnumNames(enumData),
+	}
+}
+
+// ParseEnumStrict is like ParseEnum, but it matches names exactly, without
+// folding case.  Names are tried before GoNames, which are tried before
+// aliases.  Returns InvalidEnumNameError if the string cannot be parsed.
+func ParseEnumStrict(enumName string, enumData []EnumData, str string) (uint, error) {
+	value, found := lookupEnumStrict(str, len(enumData), func(index int) EnumData {
+		return enumData[index]
+	})
+	if found {
+		return value, nil
+	}
+
+	return 0, InvalidEnumNameError{
+		Type:    enumName,
+		Name:    str,
+		Allowed: MakeActiveEnumNames(enumData),
 	}
 }
 
+// lookupEnumStrict finds the first of length enum values which has str as
+// its Name, then as its GoName, then as one of its aliases.
+func lookupEnumStrict(str string, length int, fn func(index int) EnumData) (uint, bool) {
+	if str == "" {
+		return 0, false
+	}
+	for index := 0; index < length; index++ {
+		if fn(index).Name == str {
+			return uint(index), true
+		}
+	}
+	for index := 0; index < length; index++ {
+		if fn(index).GoName == str {
+			return uint(index), true
+		}
+	}
+	for index := 0; index < length; index++ {
+		for _, alias := range fn(index).Aliases {
+			if alias == str {
+				return uint(index), true
+			}
+		}
+	}
+	return 0, false
+}
+
 // UnmarshalEnumFromJSON unmarshals an enum value from JSON.  Returns
 // IsNullError, InvalidEnumNameError, or InvalidEnumValueError if a JSON value
-// was parsed but could not be unmarshaled as an enum value.
+// was parsed but could not be unmarshaled as an enum value.  Panics if raw is
+// nil.
+//
+// UnmarshalEnumFromJSON shares its implementation with EnumType.FromJSON.
 func UnmarshalEnumFromJSON(enumName string, enumData []EnumData, raw []byte) (uint, error) {
+	return unmarshalEnumJSON(
+		enumName,
+		uint(len(enumData)),
+		func(value uint) []byte {
+			return enumData[value].JSON
+		},
+		func(str string) (uint, error) {
+			return ParseEnum(enumName, enumData, str)
+		},
+		raw,
+	)
+}
+
+// unmarshalEnumJSON unmarshals an enum value from JSON, given the number of
+// enum values, a function returning the JSON field of each value, and a
+// function parsing the string representation of a value.
+func unmarshalEnumJSON(
+	enumName string,
+	length uint,
+	jsonAt func(value uint) []byte,
+	parse func(str string) (uint, error),
+	raw []byte,
+) (uint, error) {
 	if raw == nil {
 		panic(errors.New("[]byte is nil"))
 	}
@@ -91,26 +208,25 @@ func UnmarshalEnumFromJSON(enumName string, enumData []EnumData, raw []byte) (ui
 		return 0, IsNullError{}
 	}
 
-	for index, row := range enumData {
-		if row.JSON != nil && bytes.Equal(raw, row.JSON) {
-			return uint(index), nil
+	for value := uint(0); value < length; value++ {
+		if custom := jsonAt(value); custom != nil && bytes.Equal(raw, custom) {
+			return value, nil
 		}
 	}
 
 	var str string
 	err0 := json.Unmarshal(raw, &str)
 	if err0 == nil {
-		return ParseEnum(enumName, enumData, str)
+		return parse(str)
 	}
 
 	var num uint
 	err1 := json.Unmarshal(raw, &num)
-	limit := uint(len(enumData))
-	if err1 == nil && num >= limit {
+	if err1 == nil && num >= length {
 		return 0, InvalidEnumValueError{
 			Type:  enumName,
 			Value: num,
-			Limit: limit,
+			Limit: length,
 		}
 	}
 	if err1 == nil {
@@ -118,5 +234,812 @@ func UnmarshalEnumFromJSON(enumName string, enumData []EnumData, raw []byte) (ui
 	}
 
 	return 0, err0
+}
+
+// AnnotatedEnumData extends EnumData with some auto-populated fields.
+type AnnotatedEnumData struct {
+	EnumData
+
+	// Value is the numeric value of this enum value; always equal to its
+	// index in EnumType.Data.
+	Value uint
+}
+
+// EnumType holds data about an enum type.
+type EnumType struct {
+	// Type gives the Go name for this enum type.
+	Type string
+
+	// Data lists the data for all known values, indexed by value.
+	Data []*AnnotatedEnumData
+
+	// Names holds the canonical names for all known values, indexed by
+	// value.
+	Names []string
+
+	// ByName maps valid names to the data for the corresponding value.
+	//
+	// ByName is modified by RegisterAlias and DeregisterAlias; callers
+	// which use those methods must not access ByName directly.
+	ByName map[string]*AnnotatedEnumData
+
+	opts    enumOptions
+	reg     *aliasRegistry
+	allowed []string
+}
+
+// enumOptions holds the optional behaviors configured by the EnumType.With*
+// methods.
+type enumOptions struct {
+	encodedAs   string
+	color       func(value uint) string
+	maxReadSize int64
+	validate    func(value uint) error
+	i18n        func(value uint, lang string) string
+	displayName func(value uint) string
+	icon        func(value uint) string
+}
+
+// MakeEnumType initializes and returns an EnumType.
+//
+// As with ParseEnum, if two values share a name, the name refers to the
+// value that appears first.
+func MakeEnumType(typeName string, in []EnumData) EnumType {
+	length := uint(len(in))
+
+	out := EnumType{
+		Type:   typeName,
+		Data:   make([]*AnnotatedEnumData, length),
+		Names:  make([]string, length),
+		ByName: make(map[string]*AnnotatedEnumData, 4*length),
+		reg:    new(aliasRegistry),
+	}
+	out.allowed = MakeActiveEnumNames(in)
+
+	addName := func(name string, ptr *AnnotatedEnumData) {
+		if _, found := out.ByName[name]; !found {
+			out.ByName[name] = ptr
+		}
+	}
+
+	for index := uint(0); index < length; index++ {
+		ptr := &AnnotatedEnumData{
+			EnumData: in[index],
+			Value:    index,
+		}
+
+		out.Data[index] = ptr
+		out.Names[index] = ptr.Name
+
+		if ptr.Name != "" {
+			addName(ptr.Name, ptr)
+			addName(strings.ToLower(ptr.Name), ptr)
+		}
+
+		if ptr.GoName != "" {
+			addName(ptr.GoName, ptr)
+			addName(strings.ToLower(ptr.GoName), ptr)
+		}
+
+		for _, alias := range ptr.Aliases {
+			addName(alias, ptr)
+			addName(strings.ToLower(alias), ptr)
+		}
+	}
+	return out
+}
+
+// MakeZeroEnumType returns an EnumType with no values, for building up one
+// value at a time with AddValue.
+func MakeZeroEnumType(typeName string) EnumType {
+	return MakeEnumType(typeName, nil)
+}
+
+// AddValue returns a copy of this enum type with the given value appended,
+// carrying over the options configured on this enum type.  It is equivalent
+// to ExtendWith with a single value.
+func (enum EnumType) AddValue(data EnumData) (EnumType, error) {
+	return enum.ExtendWith([]EnumData{data})
+}
+
+// ExtendWith returns a copy of this enum type with the values in extra
+// appended, carrying over the options configured on this enum type.  The new
+// values are numbered starting after the last existing value.  Returns
+// DuplicateNameError if any of the new values' names is already in use,
+// either by an existing value or by an earlier entry in extra.
+func (enum EnumType) ExtendWith(extra []EnumData) (EnumType, error) {
+	locked := enum.reg.rlock()
+	seen := make(map[string]struct{}, len(enum.ByName)+4*len(extra))
+	for name := range enum.ByName {
+		seen[strings.ToLower(name)] = struct{}{}
+	}
+	enum.reg.runlock(locked)
+
+	for _, data := range extra {
+		names := make([]string, 0, 2+len(data.Aliases))
+		names = append(names, data.Name, data.GoName)
+		names = append(names, data.Aliases...)
+		for _, name := range names {
+			if name == "" {
+				continue
+			}
+			if _, found := seen[strings.ToLower(name)]; found {
+				return EnumType{}, DuplicateNameError{
+					Type: enum.Type,
+					Name: name,
+				}
+			}
+		}
+		for _, name := range names {
+			seen[strings.ToLower(name)] = struct{}{}
+		}
+	}
+
+	in := append(enum.rawData(), extra...)
+	return enum.rebuild(in), nil
+}
+
+// rawData returns a copy of the EnumData for every enum value.
+func (enum EnumType) rawData() []EnumData {
+	out := make([]EnumData, len(enum.Data))
+	for index, row := range enum.Data {
+		out[index] = row.EnumData
+	}
+	return out
+}
+
+// rebuild returns a new enum type with the given data, carrying over the
+// options configured on this enum type.
+func (enum EnumType) rebuild(in []EnumData) EnumType {
+	out := MakeEnumType(enum.Type, in)
+	out.opts = enum.opts
+	return out
+}
+
+// TruncateTo returns a copy of this enum type which contains only the first n
+// enum values, carrying over the options configured on this enum type.
+// Returns InvalidEnumValueError if n exceeds the number of enum values.
+func (enum EnumType) TruncateTo(n uint) (EnumType, error) {
+	if limit := enum.Len(); n > limit {
+		return EnumType{}, InvalidEnumValueError{
+			Type:  enum.Type,
+			Value: n,
+			Limit: limit,
+		}
+	}
+	return enum.rebuild(enum.rawData()[:n]), nil
+}
+
+// Get returns enum.Data[value] or panics with InvalidEnumValueError.
+func (enum EnumType) Get(value uint) AnnotatedEnumData {
+	if limit := uint(len(enum.Data)); value >= limit {
+		panic(InvalidEnumValueError{
+			Type:  enum.Type,
+			Value: value,
+			Limit: limit,
+		})
+	}
+	return *enum.Data[value]
+}
+
+// ActiveValues returns the data for all values which are not deprecated, in
+// order of increasing value.
+func (enum EnumType) ActiveValues() []AnnotatedEnumData {
+	out := make([]AnnotatedEnumData, 0, len(enum.Data))
+	for _, row := range enum.Data {
+		if !row.Deprecated {
+			out = append(out, *row)
+		}
+	}
+	return out
+}
+
+// Validate returns InvalidEnumValueError if the enum value is out of range,
+// DeprecatedEnumValueError if it is deprecated, or nil otherwise.
+func (enum EnumType) Validate(value uint) error {
+	if !enum.Contains(value) {
+		return InvalidEnumValueError{
+			Type:  enum.Type,
+			Value: value,
+			Limit: enum.Len(),
+		}
+	}
+	if row := enum.Data[value]; row.Deprecated {
+		return DeprecatedEnumValueError{
+			Type:  enum.Type,
+			Value: value,
+			Name:  row.Name,
+		}
+	}
+	return nil
+}
+
+// DescribeValue returns the Description of the given enum value, or its
+// string representation if it has no Description.
+func (enum EnumType) DescribeValue(value uint) string {
+	if enum.Contains(value) && enum.Data[value].Description != "" {
+		return enum.Data[value].Description
+	}
+	return enum.ToString(value)
+}
+
+// Len returns the number of values in this enum type.
+func (enum EnumType) Len() uint {
+	return uint(len(enum.Data))
+}
+
+// Contains returns true iff value is a valid value for this enum type.
+func (enum EnumType) Contains(value uint) bool {
+	return value < uint(len(enum.Data))
+}
+
+// ForEach iterates over enum.Data with the given callback function, in order
+// of value.
+func (enum EnumType) ForEach(fn func(data AnnotatedEnumData)) {
+	for _, ptr := range enum.Data {
+		fn(*ptr)
+	}
+}
+
+// Traverse calls fn for each enum value in order, starting with start, until
+// fn returns false.  Panics with InvalidEnumValueError if start is out of
+// range.
+func (enum EnumType) Traverse(start uint, fn func(value uint, data EnumData) bool) {
+	enum.Get(start)
+	for _, ptr := range enum.Data[start:] {
+		if !fn(ptr.Value, ptr.EnumData) {
+			return
+		}
+	}
+}
+
+// ForEachInRange calls fn for each enum value which is at least lo and less
+// than hi, in order.  Values beyond the end of this enum type are ignored.
+func (enum EnumType) ForEachInRange(lo, hi uint, fn func(value uint, data EnumData)) {
+	if limit := enum.Len(); hi > limit {
+		hi = limit
+	}
+	for value := lo; value < hi; value++ {
+		fn(value, enum.Data[value].EnumData)
+	}
+}
+
+// ToGoString generates a Go string representation for the given enum value.
+func (enum EnumType) ToGoString(value uint) string {
+	if value < uint(len(enum.Data)) && enum.Data[value].GoName != "" {
+		return enum.Data[value].GoName
+	}
+	return enum.Type + "(" + strconv.FormatUint(uint64(value), 10) + ")"
+}
+
+// GoString is an alias for ToGoString.
+func (enum EnumType) GoString(value uint) string {
+	return enum.ToGoString(value)
+}
+
+// ToString generates a string representation for the given enum value.
+func (enum EnumType) ToString(value uint) string {
+	if value < uint(len(enum.Data)) && enum.Data[value].Name != "" {
+		return enum.Data[value].Name
+	}
+	return strconv.FormatUint(uint64(value), 10)
+}
+
+// String is an alias for ToString.
+func (enum EnumType) String(value uint) string {
+	return enum.ToString(value)
+}
+
+// ToJSON marshals this enum value to JSON.  Returns InvalidEnumValueError if
+// the enum value is out of range, or the error returned by the
+// WithValidationHook function.
+func (enum EnumType) ToJSON(value uint) ([]byte, error) {
+	if limit := uint(len(enum.Data)); value >= limit {
+		return nil, InvalidEnumValueError{
+			Type:  enum.Type,
+			Value: value,
+			Limit: limit,
+		}
+	}
+	if _, err := enum.validate(value); err != nil {
+		return nil, err
+	}
+	switch enum.opts.encodedAs {
+	case "goname":
+		return json.Marshal(enum.Data[value].GoName)
+	case "integer":
+		return json.Marshal(value)
+	case "hex":
+		return json.Marshal("0x" + strconv.FormatUint(uint64(value), 16))
+	default:
+		return marshalEnumData(enum.Data[value].EnumData)
+	}
+}
+
+// WithEncodedAs returns a copy of this enum type whose ToJSON method uses the
+// given format.  The supported formats are:
+//
+//	"name"     the canonical Name, or the JSON field if set (the default)
+//	"goname"   the GoName, as a string
+//	"integer"  the numeric value, as a JSON number
+//	"hex"      the numeric value in hexadecimal, as a string such as "0x2"
+//
+// FromJSON accepts all four formats regardless of this setting.  Panics if
+// format is not one of the above.
+func (enum EnumType) WithEncodedAs(format string) EnumType {
+	switch format {
+	case "name", "goname", "integer", "hex":
+		// pass
+	default:
+		panic(fmt.Errorf("unknown enum encoding %q", format))
+	}
+	out := enum
+	out.opts.encodedAs = format
+	return out
+}
+
+// FromString parses the string representation of an enum value.  Returns
+// InvalidEnumNameError if the string cannot be parsed, or the error returned
+// by the WithValidationHook function.
+func (enum EnumType) FromString(str string) (uint, error) {
+	value, err := enum.lookup(str)
+	if err != nil {
+		return 0, err
+	}
+	return enum.validate(value)
+}
+
+// lookup is like FromString, but does not call the WithValidationHook
+// function.
+func (enum EnumType) lookup(str string) (uint, error) {
+	defer enum.reg.runlock(enum.reg.rlock())
+
+	if data, found := enum.ByName[str]; found {
+		return data.Value, nil
+	}
+
+	if data, found := enum.ByName[strings.ToLower(str)]; found {
+		return data.Value, nil
+	}
+
+	return 0, InvalidEnumNameError{
+		Type:    enum.Type,
+		Name:    str,
+		Allowed: enum.allowed,
+	}
+}
+
+// Parse is an alias for FromString, named after ParseEnum.
+func (enum EnumType) Parse(str string) (uint, error) {
+	return enum.FromString(str)
+}
+
+// MustParse is like Parse, but panics with the error if the string cannot be
+// parsed.  The error names the enum type, the rejected string, and the
+// allowed names.  MustParse is intended only for use in tests and init code.
+func (enum EnumType) MustParse(str string) uint {
+	value, err := enum.Parse(str)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// ParseOrZero is like Parse, but returns 0 if the string cannot be parsed.
+func (enum EnumType) ParseOrZero(str string) uint {
+	value, err := enum.Parse(str)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// ParseOrMax is like Parse, but returns ^uint(0) if the string cannot be
+// parsed.  Unlike 0, ^uint(0) is never a valid enum value.
+func (enum EnumType) ParseOrMax(str string) uint {
+	value, err := enum.Parse(str)
+	if err != nil {
+		return ^uint(0)
+	}
+	return value
+}
+
+// ParseStrict is like FromString, but it matches names exactly, without
+// folding case, in the same manner as ParseEnumStrict.  Returns
+// InvalidEnumNameError if the string cannot be parsed, or the error returned
+// by the WithValidationHook function.
+func (enum EnumType) ParseStrict(str string) (uint, error) {
+	value, found := lookupEnumStrict(str, len(enum.Data), func(index int) EnumData {
+		return enum.Data[index].EnumData
+	})
+	if found {
+		return enum.validate(value)
+	}
+
+	return 0, InvalidEnumNameError{
+		Type:    enum.Type,
+		Name:    str,
+		Allowed: enum.allowed,
+	}
+}
+
+// MakeResolver returns a function which parses a string with FromString,
+// returning false instead of an error if the string cannot be parsed.
+func (enum EnumType) MakeResolver() func(string) (uint, bool) {
+	return func(str string) (uint, bool) {
+		value, err := enum.FromString(str)
+		if err != nil {
+			return 0, false
+		}
+		return value, true
+	}
+}
+
+// MakePredicate returns a function which returns true iff its argument is
+// equal to value.
+func (enum EnumType) MakePredicate(value uint) func(uint) bool {
+	return func(x uint) bool {
+		return x == value
+	}
+}
+
+// MakeRangePredicate returns a function which returns true iff its argument
+// is at least min and less than max.
+func (enum EnumType) MakeRangePredicate(min, max uint) func(uint) bool {
+	return func(x uint) bool {
+		return x >= min && x < max
+	}
+}
+
+// MakeConstMap returns a map from the GoName of each enum value to its
+// numeric value.  Enum values without a GoName are omitted.
+func (enum EnumType) MakeConstMap() map[string]uint {
+	out := make(map[string]uint, len(enum.Data))
+	for _, row := range enum.Data {
+		if row.GoName != "" {
+			out[row.GoName] = row.Value
+		}
+	}
+	return out
+}
+
+// MakeReverseMap returns a map from each enum value to its canonical string
+// representation: its Name, or its GoName if it has no Name.  Enum values
+// with neither are omitted.
+func (enum EnumType) MakeReverseMap() map[uint]string {
+	out := make(map[uint]string, len(enum.Data))
+	for _, row := range enum.Data {
+		if name := row.canonicalName(); name != "" {
+			out[row.Value] = name
+		}
+	}
+	return out
+}
+
+// FromJSON unmarshals an enum value from JSON.  Returns IsNullError,
+// InvalidEnumNameError, or InvalidEnumValueError if a JSON value was parsed
+// but could not be unmarshaled as an enum value, or the error returned by the
+// WithValidationHook function.  Panics if raw is nil.
+func (enum EnumType) FromJSON(raw []byte) (uint, error) {
+	value, err := unmarshalEnumJSON(
+		enum.Type,
+		enum.Len(),
+		func(value uint) []byte {
+			return enum.Data[value].JSON
+		},
+		enum.lookupJSON,
+		raw,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return enum.validate(value)
+}
+
+// lookupJSON is like lookup, but also accepts the hexadecimal strings written
+// by ToJSON in the "hex" format.
+func (enum EnumType) lookupJSON(str string) (uint, error) {
+	value, err := enum.lookup(str)
+	if err == nil || !strings.HasPrefix(str, "0x") {
+		return value, err
+	}
+	if u64, err := strconv.ParseUint(str[2:], 16, 0); err == nil && enum.Contains(uint(u64)) {
+		return uint(u64), nil
+	}
+	return value, err
+}
+
+// MustFromJSON is like FromJSON, but panics with the error if the JSON value
+// cannot be unmarshaled.
+func (enum EnumType) MustFromJSON(raw []byte) uint {
+	value, err := enum.FromJSON(raw)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// WithValidationHook returns a copy of this enum type whose FromString,
+// FromJSON, ToJSON, FromYAML, ScanEnum, and UnmarshalProtoJSON methods call fn
+// with each value that they resolve.  If fn returns an error, the method
+// returns that error.
+func (enum EnumType) WithValidationHook(fn func(value uint) error) EnumType {
+	out := enum
+	out.opts.validate = fn
+	return out
+}
+
+// validate calls the WithValidationHook function, if any, and returns value
+// if it succeeds.
+func (enum EnumType) validate(value uint) (uint, error) {
+	if enum.opts.validate != nil {
+		if err := enum.opts.validate(value); err != nil {
+			return 0, err
+		}
+	}
+	return value, nil
+}
+
+// ansiReset is the ANSI escape sequence which resets all text attributes.
+const ansiReset = "\x1b[0m"
 
+// WithColor returns a copy of this enum type whose ToColorString method uses
+// fn to choose the ANSI escape sequence for each value.
+func (enum EnumType) WithColor(fn func(value uint) string) EnumType {
+	out := enum
+	out.opts.color = fn
+	return out
+}
+
+// ToColorString generates a string representation for the given enum value,
+// wrapped in the ANSI escape sequence chosen by the WithColor function.  If
+// there is no such function, or if it returns the empty string, this is the
+// same as ToString.
+func (enum EnumType) ToColorString(value uint) string {
+	str := enum.ToString(value)
+	if enum.opts.color == nil {
+		return str
+	}
+	color := enum.opts.color(value)
+	if color == "" {
+		return str
+	}
+	return color + str + ansiReset
+}
+
+// WithI18N returns a copy of this enum type whose ToLocalizedString method
+// uses fn to translate each value into the given language.
+func (enum EnumType) WithI18N(fn func(value uint, lang string) string) EnumType {
+	out := enum
+	out.opts.i18n = fn
+	return out
+}
+
+// ToLocalizedString generates a string representation for the given enum
+// value in the given language, as returned by the WithI18N function.  If there
+// is no such function, or if it returns the empty string, this is the same as
+// ToString.
+func (enum EnumType) ToLocalizedString(value uint, lang string) string {
+	if enum.opts.i18n != nil {
+		if str := enum.opts.i18n(value, lang); str != "" {
+			return str
+		}
+	}
+	return enum.ToString(value)
+}
+
+// WithDisplayName returns a copy of this enum type whose ToDisplayString
+// method uses fn to format each value for display.
+func (enum EnumType) WithDisplayName(fn func(value uint) string) EnumType {
+	out := enum
+	out.opts.displayName = fn
+	return out
+}
+
+// ToDisplayString generates a display name for the given enum value, as
+// returned by the WithDisplayName function.  If there is no such function, or
+// if it returns the empty string, this is the same as ToString.
+func (enum EnumType) ToDisplayString(value uint) string {
+	if enum.opts.displayName != nil {
+		if str := enum.opts.displayName(value); str != "" {
+			return str
+		}
+	}
+	return enum.ToString(value)
+}
+
+// WithValueIcon returns a copy of this enum type whose ToIconString method
+// uses fn to choose an icon, such as an emoji, for each value.
+func (enum EnumType) WithValueIcon(fn func(value uint) string) EnumType {
+	out := enum
+	out.opts.icon = fn
+	return out
+}
+
+// ToIconString generates a string representation for the given enum value,
+// prefixed by the icon chosen by the WithValueIcon function and a space.  If
+// there is no such function, or if it returns the empty string, this is the
+// same as ToString.
+func (enum EnumType) ToIconString(value uint) string {
+	str := enum.ToString(value)
+	if enum.opts.icon == nil {
+		return str
+	}
+	icon := enum.opts.icon(value)
+	if icon == "" {
+		return str
+	}
+	return icon + " " + str
+}
+
+// RegisterAlias adds alias as an additional name for the given enum value.  It
+// is safe to call concurrently with FromString and FromJSON.  The enum type
+// must have been created by MakeEnumType.
+//
+// The alias is visible to every copy of this enum type, but not to new types
+// derived from it by methods such as ExtendWith.  Returns
+// InvalidEnumValueError if value is out of range, or DuplicateNameError if
+// alias is already in use.  Panics if the enum type has been frozen.
+func (enum EnumType) RegisterAlias(value uint, alias string) error {
+	if limit := enum.Len(); value >= limit {
+		return InvalidEnumValueError{
+			Type:  enum.Type,
+			Value: value,
+			Limit: limit,
+		}
+	}
+
+	reg := enum.reg
+	reg.lockForWrite(enum.Type)
+	defer reg.mu.Unlock()
+
+	lower := strings.ToLower(alias)
+	_, found0 := enum.ByName[alias]
+	_, found1 := enum.ByName[lower]
+	if alias == "" || found0 || found1 {
+		return DuplicateNameError{
+			Type: enum.Type,
+			Name: alias,
+		}
+	}
+
+	if reg.aliases == nil {
+		reg.aliases = make(map[string]struct{})
+	}
+	reg.aliases[alias] = struct{}{}
+	ptr := enum.Data[value]
+	enum.ByName[alias] = ptr
+	enum.ByName[lower] = ptr
+	return nil
+}
+
+// DeregisterAlias removes an alias previously added by RegisterAlias.  It is
+// safe to call concurrently with FromString and FromJSON.  Returns
+// InvalidEnumNameError if alias was not registered by RegisterAlias.  Panics
+// if the enum type has been frozen.
+func (enum EnumType) DeregisterAlias(alias string) error {
+	reg := enum.reg
+	reg.lockForWrite(enum.Type)
+	defer reg.mu.Unlock()
+
+	if _, found := reg.aliases[alias]; !found {
+		return InvalidEnumNameError{
+			Type: enum.Type,
+			Name: alias,
+		}
+	}
+
+	delete(reg.aliases, alias)
+	delete(enum.ByName, alias)
+	delete(enum.ByName, strings.ToLower(alias))
+	return nil
+}
+
+// Freeze marks this enum type, and every copy of it, as immutable.  After
+// Freeze returns, RegisterAlias and DeregisterAlias will panic, and lookups by
+// name no longer need to acquire a lock.  Calling Freeze more than once is
+// harmless.
+func (enum EnumType) Freeze() {
+	reg := enum.reg
+	if reg == nil {
+		return
+	}
+	reg.mu.Lock()
+	atomic.StoreUint32(&reg.frozen, 1)
+	reg.mu.Unlock()
+}
+
+// WithMaxReadSize returns a copy of this enum type whose ParseFromReader
+// method reads at most n bytes.  If n <= 0, DefaultMaxReadSize is used.
+func (enum EnumType) WithMaxReadSize(n int64) EnumType {
+	out := enum
+	out.opts.maxReadSize = n
+	return out
+}
+
+// ParseFromReader reads all bytes from r, trims surrounding whitespace, and
+// parses the result with FromString.  Returns InputTooLargeError if r holds
+// more than the limit set by WithMaxReadSize.
+func (enum EnumType) ParseFromReader(r io.Reader) (uint, error) {
+	str, err := readAllLimited(r, enum.Type, enum.opts.maxReadSize)
+	if err != nil {
+		return 0, err
+	}
+	return enum.FromString(str)
+}
+
+// AllAliases returns every string which FromString resolves to the given
+// enum value: its Name, GoName, and aliases, including those added by
+// RegisterAlias, followed by their lower case forms, without duplicates.  Names which are shadowed by an earlier enum
+// value are omitted.  Returns nil if the enum value is out of range.
+func (enum EnumType) AllAliases(value uint) []string {
+	if !enum.Contains(value) {
+		return nil
+	}
+
+	defer enum.reg.runlock(enum.reg.rlock())
+
+	row := enum.Data[value]
+	names := make([]string, 0, 2+len(row.Aliases))
+	names = append(names, row.Name, row.GoName)
+	names = append(names, row.Aliases...)
+	registered := make([]string, 0, len(enum.reg.aliases))
+	for alias := range enum.reg.aliases {
+		if enum.ByName[alias].Value == value {
+			registered = append(registered, alias)
+		}
+	}
+	sort.Strings(registered)
+	names = append(names, registered...)
+
+	return collectAliases(names, func(name string) bool {
+		data, found := enum.ByName[name]
+		return found && data.Value == value
+	})
+}
+
+// collectAliases returns the non-empty names, followed by their lower case
+// forms, for which fn returns true, without duplicates.
+func collectAliases(names []string, fn func(name string) bool) []string {
+	out := make([]string, 0, 2*len(names))
+	seen := make(map[string]struct{}, 2*len(names))
+	add := func(name string) {
+		if _, found := seen[name]; found || name == "" || !fn(name) {
+			return
+		}
+		seen[name] = struct{}{}
+		out = append(out, name)
+	}
+	for _, name := range names {
+		add(name)
+	}
+	for _, name := range names {
+		add(strings.ToLower(name))
+	}
+	return out
+}
+
+// MakeEnumSetType returns a BitfieldType, named after this enum type with a
+// "Set" suffix, in which bit i represents enum value i.  Each bit has the
+// same names and description as the corresponding enum value.  Returns
+// InvalidBitfieldIndexError if this enum type has more than 64 values.
+func (enum EnumType) MakeEnumSetType() (BitfieldType, error) {
+	if length := enum.Len(); length > 64 {
+		return BitfieldType{}, InvalidBitfieldIndexError{
+			Type:  enum.Type + "Set",
+			Index: length - 1,
+			Limit: 64,
+		}
+	}
+
+	in := make([]BitfieldData, len(enum.Data))
+	for index, row := range enum.Data {
+		in[index] = BitfieldData{
+			GoName:      row.GoName,
+			Name:        row.Name,
+			Aliases:     row.Aliases,
+			Description: row.Description,
+		}
+	}
+	return MakeBitfieldType(enum.Type+"Set", in), nil
 }
@@ -0,0 +1,733 @@
+package enumhelper
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+var testColorData = []EnumData{
+	{GoName: "ColorRed", Name: "red"},
+	{GoName: "ColorGreen", Name: "green"},
+	{GoName: "ColorBlue", Name: "blue", Aliases: []string{"azure"}},
+	{GoName: "ColorPurple", Name: "purple"},
+}
+
+var errNoBlue = errors.New("blue is not allowed")
+
+// rejectBlue is a validation hook which rejects ColorBlue.
+func rejectBlue(value uint) error {
+	if value == 2 {
+		return errNoBlue
+	}
+	return nil
+}
+
+func TestEnumType_WithValidationHook(t *testing.T) {
+	color := MakeEnumType("Color", testColorData).WithValidationHook(rejectBlue)
+
+	type testCase struct {
+		Name  string
+		Parse func() (uint, error)
+		Value uint
+		Err   error
+	}
+
+	fromYAML := func(str string) func() (uint, error) {
+		return func() (uint, error) {
+			var node yaml.Node
+			if err := yaml.Unmarshal([]byte(str), &node); err != nil {
+				return 0, err
+			}
+			return color.FromYAML(&node)
+		}
+	}
+
+	scan := func(src interface{}) func() (uint, error) {
+		return func() (uint, error) {
+			var u uint
+			err := color.ScanEnum(src, &u)
+			return u, err
+		}
+	}
+
+	protoJSON := func(str string) func() (uint, error) {
+		return func() (uint, error) {
+			return color.UnmarshalProtoJSON([]byte(str))
+		}
+	}
+
+	testData := []testCase{
+		{"FromString/ok", func() (uint, error) { return color.FromString("green") }, 1, nil},
+		{"FromString/rejected", func() (uint, error) { return color.FromString("azure") }, 0, errNoBlue},
+		{"FromJSON/string", func() (uint, error) { return color.FromJSON([]byte(`"blue"`)) }, 0, errNoBlue},
+		{"FromJSON/number", func() (uint, error) { return color.FromJSON([]byte(`2`)) }, 0, errNoBlue},
+		{"FromYAML/string", fromYAML(`blue`), 0, errNoBlue},
+		{"FromYAML/int/ok", fromYAML(`3`), 3, nil},
+		{"FromYAML/int/rejected", fromYAML(`2`), 0, errNoBlue},
+		{"ScanEnum/string", scan("blue"), 0, errNoBlue},
+		{"ScanEnum/bytes", scan([]byte("blue")), 0, errNoBlue},
+		{"ScanEnum/int64/ok", scan(int64(1)), 1, nil},
+		{"ScanEnum/int64/rejected", scan(int64(2)), 0, errNoBlue},
+		{"UnmarshalProtoJSON/name", protoJSON(`"COLOR_BLUE"`), 0, errNoBlue},
+		{"UnmarshalProtoJSON/string", protoJSON(`"2"`), 0, errNoBlue},
+		{"UnmarshalProtoJSON/number", protoJSON(`2`), 0, errNoBlue},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			value, err := row.Parse()
+			if !errors.Is(err, row.Err) {
+				t.Fatalf("expected error %v, got %v", row.Err, err)
+			}
+			if value != row.Value {
+				t.Errorf("expected value %d, got %d", row.Value, value)
+			}
+		})
+	}
+}
+
+// makeBenchEnumData returns n enum values named "value0" through "valueN".
+func makeBenchEnumData(n int) []EnumData {
+	data := make([]EnumData, n)
+	for index := range data {
+		name := "value" + strconv.Itoa(index)
+		data[index] = EnumData{GoName: "Bench" + name, Name: name}
+	}
+	return data
+}
+
+var (
+	benchEnumData      = makeBenchEnumData(50)
+	benchEnum          = MakeEnumType("Bench", benchEnumData)
+	benchEnumDataLarge = makeBenchEnumData(1000)
+	benchEnumLarge     = MakeEnumType("Bench", benchEnumDataLarge)
+)
+
+// The benchmarks below look up the last value, which is the worst case for
+// the linear scan done by the free functions.
+
+func BenchmarkEnumFromString(b *testing.B) {
+	str := benchEnumData[len(benchEnumData)-1].Name
+	b.Run("free", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = ParseEnum("Bench", benchEnumData, str)
+		}
+	})
+	b.Run("struct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = benchEnum.FromString(str)
+		}
+	})
+}
+
+func BenchmarkEnumToJSON(b *testing.B) {
+	value := uint(len(benchEnumData) - 1)
+	b.Run("free", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = MarshalEnumToJSON("Bench", benchEnumData, value)
+		}
+	})
+	b.Run("struct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = benchEnum.ToJSON(value)
+		}
+	})
+}
+
+func benchmarkEnumFromJSON(b *testing.B, enumData []EnumData, enum EnumType) {
+	raw := []byte(strconv.Quote(enumData[len(enumData)-1].Name))
+	b.Run("free", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = UnmarshalEnumFromJSON("Bench", enumData, raw)
+		}
+	})
+	b.Run("struct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = enum.FromJSON(raw)
+		}
+	})
+}
+
+func BenchmarkEnumFromJSON(b *testing.B) {
+	benchmarkEnumFromJSON(b, benchEnumData, benchEnum)
+}
+
+func BenchmarkEnumFromJSONLarge(b *testing.B) {
+	benchmarkEnumFromJSON(b, benchEnumDataLarge, benchEnumLarge)
+}
+
+// recoverError calls fn and returns the error it panicked with, or nil.
+func recoverError(fn func()) (err error) {
+	defer func() {
+		if x := recover(); x != nil {
+			err, _ = x.(error)
+		}
+	}()
+	fn()
+	return nil
+}
+
+func TestEnumType_MustFromJSON(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	if value := color.MustFromJSON([]byte(`"green"`)); value != 1 {
+		t.Errorf("MustFromJSON(green): expected 1, got %d", value)
+	}
+
+	err := recoverError(func() { color.MustFromJSON([]byte(`null`)) })
+	if _, ok := err.(IsNullError); !ok {
+		t.Errorf("MustFromJSON(null): expected panic with IsNullError, got %#v", err)
+	}
+
+	err = recoverError(func() { color.MustFromJSON([]byte(`"pink"`)) })
+	if x, ok := err.(InvalidEnumNameError); !ok || x.Name != "pink" {
+		t.Errorf("MustFromJSON(pink): expected panic with InvalidEnumNameError, got %#v", err)
+	}
+}
+
+func TestEnumType_WithEncodedAs(t *testing.T) {
+	type testCase struct {
+		format   string
+		expected string
+	}
+
+	testData := [...]testCase{
+		{"name", `"blue"`},
+		{"goname", `"ColorBlue"`},
+		{"integer", `2`},
+		{"hex", `"0x2"`},
+	}
+
+	for _, row := range testData {
+		t.Run(row.format, func(t *testing.T) {
+			color := MakeEnumType("Color", testColorData).WithEncodedAs(row.format)
+			raw, err := color.ToJSON(2)
+			if err != nil {
+				t.Fatalf("ToJSON(2): unexpected error: %v", err)
+			}
+			if string(raw) != row.expected {
+				t.Errorf("ToJSON(2): expected %s, got %s", row.expected, raw)
+			}
+			value, err := color.FromJSON(raw)
+			if err != nil {
+				t.Fatalf("FromJSON(%s): unexpected error: %v", raw, err)
+			}
+			if value != 2 {
+				t.Errorf("FromJSON(%s): expected 2, got %d", raw, value)
+			}
+		})
+	}
+
+	err := recoverError(func() { MakeEnumType("Color", testColorData).WithEncodedAs("octal") })
+	if err == nil {
+		t.Error("WithEncodedAs(octal): expected panic, got none")
+	}
+}
+
+func TestEnumType_WithI18N(t *testing.T) {
+	french := []string{"rouge", "vert", "bleu"}
+	color := MakeEnumType("Color", testColorData).WithI18N(func(value uint, lang string) string {
+		if lang == "fr" && value < uint(len(french)) {
+			return french[value]
+		}
+		return ""
+	})
+
+	type testCase struct {
+		value    uint
+		lang     string
+		expected string
+	}
+
+	testData := [...]testCase{
+		{0, "fr", "rouge"},
+		{2, "fr", "bleu"},
+		{2, "en", "blue"},
+		{3, "fr", "purple"},
+	}
+
+	for _, row := range testData {
+		if actual := color.ToLocalizedString(row.value, row.lang); actual != row.expected {
+			t.Errorf("ToLocalizedString(%d, %q): expected %q, got %q", row.value, row.lang, row.expected, actual)
+		}
+	}
+
+	plain := MakeEnumType("Color", testColorData)
+	if actual := plain.ToLocalizedString(1, "fr"); actual != "green" {
+		t.Errorf("ToLocalizedString without WithI18N: expected %q, got %q", "green", actual)
+	}
+}
+
+func TestEnumType_WithDisplayName(t *testing.T) {
+	status := MakeEnumType("Status", []EnumData{
+		{GoName: "StatusNew", Name: "new"},
+		{GoName: "StatusDone", Name: "done"},
+		{GoName: "StatusInProgress", Name: "in_progress"},
+	})
+
+	titleCase := func(value uint) string {
+		words := strings.Split(status.ToString(value), "_")
+		for i, word := range words {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+		return strings.Join(words, " ")
+	}
+
+	if actual := status.ToDisplayString(2); actual != "in_progress" {
+		t.Errorf("ToDisplayString(2) without WithDisplayName: expected %q, got %q", "in_progress", actual)
+	}
+
+	status = status.WithDisplayName(titleCase)
+	if actual := status.ToDisplayString(2); actual != "In Progress" {
+		t.Errorf("ToDisplayString(2): expected %q, got %q", "In Progress", actual)
+	}
+	if actual := status.ToString(2); actual != "in_progress" {
+		t.Errorf("ToString(2): expected %q, got %q", "in_progress", actual)
+	}
+
+	status = status.WithDisplayName(func(value uint) string { return "" })
+	if actual := status.ToDisplayString(1); actual != "done" {
+		t.Errorf("ToDisplayString(1) with empty display name: expected %q, got %q", "done", actual)
+	}
+}
+
+func TestEnumType_WithValueIcon(t *testing.T) {
+	result := MakeEnumType("Result", []EnumData{
+		{GoName: "ResultSuccess", Name: "success"},
+		{GoName: "ResultError", Name: "error"},
+		{GoName: "ResultSkipped", Name: "skipped"},
+	}).WithValueIcon(func(value uint) string {
+		switch value {
+		case 0:
+			return "✅"
+		case 1:
+			return "❌"
+		default:
+			return ""
+		}
+	})
+
+	type testCase struct {
+		value    uint
+		expected string
+	}
+
+	testData := [...]testCase{
+		{0, "✅ success"},
+		{1, "❌ error"},
+		{2, "skipped"},
+	}
+
+	for _, row := range testData {
+		if actual := result.ToIconString(row.value); actual != row.expected {
+			t.Errorf("ToIconString(%d): expected %q, got %q", row.value, row.expected, actual)
+		}
+	}
+}
+
+func TestEnumType_ParseOrZero(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	type testCase struct {
+		Input string
+		Zero  uint
+		Max   uint
+	}
+
+	testData := []testCase{
+		{"red", 0, 0},
+		{"blue", 2, 2},
+		{"AZURE", 2, 2},
+		{"pink", 0, ^uint(0)},
+		{"", 0, ^uint(0)},
+	}
+
+	for _, row := range testData {
+		if value := color.ParseOrZero(row.Input); value != row.Zero {
+			t.Errorf("ParseOrZero(%q): expected %d, got %d", row.Input, row.Zero, value)
+		}
+		if value := color.ParseOrMax(row.Input); value != row.Max {
+			t.Errorf("ParseOrMax(%q): expected %d, got %d", row.Input, row.Max, value)
+		}
+	}
+
+	if color.Contains(color.ParseOrMax("pink")) {
+		t.Errorf("ParseOrMax: failure value is a valid enum value")
+	}
+}
+
+func TestEnumType_MakeConstMap(t *testing.T) {
+	data := append([]EnumData(nil), testColorData...)
+	data = append(data, EnumData{Name: "unnamed"})
+	m := MakeEnumType("Color", data).MakeConstMap()
+
+	if len(m) != len(testColorData) {
+		t.Errorf("expected %d entries, got %d: %v", len(testColorData), len(m), m)
+	}
+	for index, row := range testColorData {
+		if value, found := m[row.GoName]; !found || value != uint(index) {
+			t.Errorf("%s: expected %d, true; got %d, %v", row.GoName, index, value, found)
+		}
+	}
+}
+
+func TestEnumType_MakeReverseMap(t *testing.T) {
+	m := MakeEnumType("Color", testColorData).MakeReverseMap()
+
+	if len(m) != len(testColorData) {
+		t.Errorf("expected %d entries, got %d: %v", len(testColorData), len(m), m)
+	}
+	for index, row := range testColorData {
+		if name := m[uint(index)]; name != row.Name {
+			t.Errorf("%d: expected %q, got %q", index, row.Name, name)
+		}
+	}
+}
+
+func TestEnumType_TruncateTo(t *testing.T) {
+	color, err := MakeEnumType("Color", testColorData).TruncateTo(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, str := range []string{"red", "green", "blue"} {
+		if _, err := color.FromString(str); err != nil {
+			t.Errorf("FromString(%q): %v", str, err)
+		}
+	}
+	if _, err := color.FromString("purple"); !errors.Is(err, ErrInvalidEnumName) {
+		t.Errorf("FromString(purple): expected %v, got %v", ErrInvalidEnumName, err)
+	}
+	if _, err := color.FromJSON([]byte(`3`)); !errors.Is(err, ErrInvalidEnumValue) {
+		t.Errorf("FromJSON(3): expected %v, got %v", ErrInvalidEnumValue, err)
+	}
+
+	if _, err := color.TruncateTo(4); !errors.Is(err, ErrInvalidEnumValue) {
+		t.Errorf("TruncateTo(4): expected %v, got %v", ErrInvalidEnumValue, err)
+	}
+}
+
+func TestEnumType_ExtendWith(t *testing.T) {
+	base := MakeEnumType("Color", testColorData[:2])
+	color, err := base.ExtendWith(testColorData[2:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := color.FromString("azure"); err != nil || value != 2 {
+		t.Errorf("FromString(azure): expected 2, nil; got %d, %v", value, err)
+	}
+	if value, err := color.FromString("purple"); err != nil || value != 3 {
+		t.Errorf("FromString(purple): expected 3, nil; got %d, %v", value, err)
+	}
+
+	conflicts := [][]EnumData{
+		{{Name: "RED"}},
+		{{Name: "cyan", Aliases: []string{"ColorGreen"}}},
+		{{Name: "cyan"}, {Name: "Cyan"}},
+	}
+	for _, extra := range conflicts {
+		if _, err := base.ExtendWith(extra); !errors.Is(err, ErrDuplicateName) {
+			t.Errorf("ExtendWith(%v): expected %v, got %v", extra, ErrDuplicateName, err)
+		}
+	}
+}
+
+func TestMakeActiveEnumNames(t *testing.T) {
+	data := []EnumData{
+		{GoName: "ColorRed", Name: "red"},
+		{GoName: "ColorMaroon", Name: "maroon", Deprecated: true},
+		{GoName: "ColorBlue", Name: "blue"},
+	}
+
+	if names := MakeAllowedEnumNames(data); strings.Join(names, ",") != "red,maroon,blue" {
+		t.Errorf("MakeAllowedEnumNames: expected [red maroon blue], got %q", names)
+	}
+	if names := MakeActiveEnumNames(data); strings.Join(names, ",") != "red,blue" {
+		t.Errorf("MakeActiveEnumNames: expected [red blue], got %q", names)
+	}
+
+	color := MakeEnumType("Color", data)
+	if value, err := color.FromString("maroon"); err != nil || value != 1 {
+		t.Errorf("FromString(maroon): expected 1, nil; got %d, %v", value, err)
+	}
+	var x InvalidEnumNameError
+	if _, err := color.FromString("pink"); !errors.As(err, &x) || strings.Join(x.Allowed, ",") != "red,blue" {
+		t.Errorf("FromString(pink): expected InvalidEnumNameError allowing [red blue], got %#v", err)
+	}
+}
+
+func TestEnumType_Contains(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	for value := uint(0); value < 4; value++ {
+		if !color.Contains(value) {
+			t.Errorf("Contains(%d): expected true, got false", value)
+		}
+	}
+	for _, value := range []uint{4, 5, ^uint(0)} {
+		if color.Contains(value) {
+			t.Errorf("Contains(%d): expected false, got true", value)
+		}
+	}
+}
+
+func TestEnumType_String(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	for value := uint(0); value <= color.Len(); value++ {
+		if a, b := color.String(value), color.ToString(value); a != b {
+			t.Errorf("String(%d): expected %q (ToString), got %q", value, b, a)
+		}
+		if a, b := color.GoString(value), color.ToGoString(value); a != b {
+			t.Errorf("GoString(%d): expected %q (ToGoString), got %q", value, b, a)
+		}
+	}
+}
+
+func TestEnumType_DescribeValue(t *testing.T) {
+	color := MakeEnumType("Color", []EnumData{
+		{GoName: "ColorRed", Name: "red", Description: "the color of blood"},
+		{GoName: "ColorGreen", Name: "green"},
+	})
+
+	type testCase struct {
+		Value  uint
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{0, "the color of blood"},
+		{1, "green"},
+		{2, color.ToString(2)},
+	}
+
+	for _, row := range testData {
+		if got := color.DescribeValue(row.Value); got != row.Expect {
+			t.Errorf("DescribeValue(%d): expected %q, got %q", row.Value, row.Expect, got)
+		}
+	}
+}
+
+func TestEnumType_MustParse(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	if value := color.MustParse("azure"); value != 2 {
+		t.Errorf("MustParse(\"azure\"): expected 2, got %d", value)
+	}
+
+	err := recoverError(func() { color.MustParse("chartreuse") })
+	if !errors.Is(err, ErrInvalidEnumName) {
+		t.Fatalf("MustParse(\"chartreuse\"): expected panic with %v, got %v", ErrInvalidEnumName, err)
+	}
+	msg := err.Error()
+	for _, want := range []string{"Color", `"chartreuse"`, `"red"`, `"green"`, `"blue"`, `"purple"`} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("MustParse(\"chartreuse\"): expected panic message to contain %s, got %q", want, msg)
+		}
+	}
+}
+
+func TestEnumType_ForEachInRange(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	type testCase struct {
+		Name   string
+		Lo     uint
+		Hi     uint
+		Expect []uint
+	}
+
+	testData := [...]testCase{
+		{"all", 0, 4, []uint{0, 1, 2, 3}},
+		{"middle", 1, 3, []uint{1, 2}},
+		{"beyond-end", 2, 100, []uint{2, 3}},
+		{"past-end", 4, 100, nil},
+		{"empty", 2, 2, nil},
+		{"inverted", 3, 1, nil},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var got []uint
+			color.ForEachInRange(row.Lo, row.Hi, func(value uint, data EnumData) {
+				if value < row.Lo || value >= row.Hi {
+					t.Errorf("unexpected call for %d outside [%d, %d)", value, row.Lo, row.Hi)
+				}
+				if data.Name != testColorData[value].Name {
+					t.Errorf("value %d: expected data %q, got %q", value, testColorData[value].Name, data.Name)
+				}
+				got = append(got, value)
+			})
+			if len(got) != len(row.Expect) {
+				t.Fatalf("expected %v, got %v", row.Expect, got)
+			}
+			for i := range got {
+				if got[i] != row.Expect[i] {
+					t.Errorf("expected %v, got %v", row.Expect, got)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestEnumType_RegisterAlias(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	if err := color.RegisterAlias(0, "Crimson"); err != nil {
+		t.Fatal(err)
+	}
+	for _, str := range []string{"Crimson", "crimson", "CRIMSON"} {
+		if value, err := color.FromString(str); err != nil || value != 0 {
+			t.Errorf("FromString(%q): expected 0, nil; got %d, %v", str, value, err)
+		}
+	}
+
+	// Copies share the registered alias; derived types do not.
+	if value, err := color.WithEncodedAs("goname").FromString("crimson"); err != nil || value != 0 {
+		t.Errorf("FromString on copy: expected 0, nil; got %d, %v", value, err)
+	}
+	extended, err := color.AddValue(EnumData{GoName: "ColorOrange", Name: "orange"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := extended.FromString("crimson"); !errors.Is(err, ErrInvalidEnumName) {
+		t.Errorf("FromString on derived type: expected %v, got %v", ErrInvalidEnumName, err)
+	}
+
+	if got, expect := strings.Join(color.AllAliases(0), ","), "red,ColorRed,Crimson,colorred,crimson"; got != expect {
+		t.Errorf("AllAliases: expected %q, got %q", expect, got)
+	}
+
+	if err := color.RegisterAlias(1, "crimson"); !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("RegisterAlias duplicate: expected %v, got %v", ErrDuplicateName, err)
+	}
+	if err := color.RegisterAlias(1, "Azure"); !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("RegisterAlias of a built-in alias: expected %v, got %v", ErrDuplicateName, err)
+	}
+	if err := color.RegisterAlias(4, "ultraviolet"); !errors.Is(err, ErrInvalidEnumValue) {
+		t.Errorf("RegisterAlias out of range: expected %v, got %v", ErrInvalidEnumValue, err)
+	}
+
+	if err := color.DeregisterAlias("Crimson"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := color.FromString("crimson"); !errors.Is(err, ErrInvalidEnumName) {
+		t.Errorf("FromString after DeregisterAlias: expected %v, got %v", ErrInvalidEnumName, err)
+	}
+	if err := color.DeregisterAlias("Crimson"); !errors.Is(err, ErrInvalidEnumName) {
+		t.Errorf("DeregisterAlias twice: expected %v, got %v", ErrInvalidEnumName, err)
+	}
+	if err := color.DeregisterAlias("azure"); !errors.Is(err, ErrInvalidEnumName) {
+		t.Errorf("DeregisterAlias of a built-in alias: expected %v, got %v", ErrInvalidEnumName, err)
+	}
+}
+
+func TestEnumType_RegisterAlias_Concurrent(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	const numAliases = 100
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numAliases; i++ {
+			alias := "alias" + strconv.Itoa(i)
+			if err := color.RegisterAlias(uint(i%4), alias); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numAliases; i++ {
+			alias := "temp" + strconv.Itoa(i)
+			if err := color.RegisterAlias(uint(i%4), alias); err != nil {
+				t.Error(err)
+				return
+			}
+			if err := color.DeregisterAlias(alias); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numAliases; i++ {
+			if value, err := color.FromString("blue"); err != nil || value != 2 {
+				t.Errorf("FromString: expected 2, nil; got %d, %v", value, err)
+				return
+			}
+			_, _ = color.FromString("alias" + strconv.Itoa(i))
+			_ = color.AllAliases(uint(i % 4))
+		}
+	}()
+	wg.Wait()
+
+	for i := 0; i < numAliases; i++ {
+		alias := "alias" + strconv.Itoa(i)
+		if value, err := color.FromString(alias); err != nil || value != uint(i%4) {
+			t.Errorf("FromString(%q): expected %d, nil; got %d, %v", alias, i%4, value, err)
+		}
+		if _, err := color.FromString("temp" + strconv.Itoa(i)); !errors.Is(err, ErrInvalidEnumName) {
+			t.Errorf("FromString(temp%d): expected %v, got %v", i, ErrInvalidEnumName, err)
+		}
+	}
+}
+
+func TestEnumType_Freeze(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+	if err := color.RegisterAlias(0, "crimson"); err != nil {
+		t.Fatal(err)
+	}
+	color.Freeze()
+	color.Freeze()
+
+	if value, err := color.FromString("Crimson"); err != nil || value != 0 {
+		t.Errorf("FromString: expected 0, nil; got %d, %v", value, err)
+	}
+	if value, err := color.FromString("azure"); err != nil || value != 2 {
+		t.Errorf("FromString: expected 2, nil; got %d, %v", value, err)
+	}
+	if value, err := color.FromJSON([]byte(`"purple"`)); err != nil || value != 3 {
+		t.Errorf("FromJSON: expected 3, nil; got %d, %v", value, err)
+	}
+
+	expectPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected panic on a frozen type", name)
+			}
+		}()
+		fn()
+	}
+
+	// Copies made before or after Freeze share the frozen state.
+	copied := color.WithEncodedAs("goname")
+	expectPanic("RegisterAlias", func() { _ = color.RegisterAlias(1, "lime") })
+	expectPanic("RegisterAlias on copy", func() { _ = copied.RegisterAlias(1, "lime") })
+	expectPanic("DeregisterAlias", func() { _ = color.DeregisterAlias("crimson") })
+
+	// Derived types are not frozen.
+	extended, err := color.AddValue(EnumData{GoName: "ColorOrange", Name: "orange"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := extended.RegisterAlias(4, "amber"); err != nil {
+		t.Errorf("RegisterAlias on derived type: unexpected error: %v", err)
+	}
+}
@@ -7,12 +7,121 @@ import (
 
 var nullBytes = []byte("null")
 
+// Sentinel values for use with errors.Is.  Each one matches any error of the
+// corresponding type, regardless of the error's field values, as does any
+// other value of that type.
+var (
+	// ErrNullJSON matches IsNullError.
+	ErrNullJSON = IsNullError{}
+
+	// ErrInvalidEnumName matches InvalidEnumNameError.
+	ErrInvalidEnumName InvalidEnumNameError
+
+	// ErrInvalidEnumValue matches InvalidEnumValueError.
+	ErrInvalidEnumValue InvalidEnumValueError
+
+	// ErrUnknownEnumValue matches UnknownEnumValueError.
+	ErrUnknownEnumValue UnknownEnumValueError
+
+	// ErrInvalidBitfieldName matches InvalidBitfieldNameError.
+	ErrInvalidBitfieldName InvalidBitfieldNameError
+
+	// ErrInvalidBitfieldIndex matches InvalidBitfieldIndexError.
+	ErrInvalidBitfieldIndex InvalidBitfieldIndexError
+
+	// ErrDuplicateName matches DuplicateNameError.
+	ErrDuplicateName DuplicateNameError
+
+	// ErrDuplicateBitfieldIndex matches DuplicateBitfieldIndexError.
+	ErrDuplicateBitfieldIndex DuplicateBitfieldIndexError
+
+	// ErrInputTooLarge matches InputTooLargeError.
+	ErrInputTooLarge InputTooLargeError
+
+	// ErrDeprecatedEnumValue matches DeprecatedEnumValueError.
+	ErrDeprecatedEnumValue DeprecatedEnumValueError
+
+	// ErrMultiBitfieldParse matches MultiBitfieldParseError.
+	ErrMultiBitfieldParse MultiBitfieldParseError
+
+	// ErrStaleDiff matches StaleDiffError.
+	ErrStaleDiff StaleDiffError
+
+	// ErrInvalidIdentifier matches InvalidIdentifierError.
+	ErrInvalidIdentifier InvalidIdentifierError
+)
+
 // IsNull returns true iff err is an instance of IsNullError.
 func IsNull(err error) bool {
 	var x IsNullError
 	return errors.As(err, &x)
 }
 
+// IsInvalidEnumName returns true iff err is an instance of InvalidEnumNameError.
+func IsInvalidEnumName(err error) bool {
+	var x InvalidEnumNameError
+	return errors.As(err, &x)
+}
+
+// AsInvalidEnumName returns the InvalidEnumNameError in err's chain, if any.
+func AsInvalidEnumName(err error) (InvalidEnumNameError, bool) {
+	var x InvalidEnumNameError
+	ok := errors.As(err, &x)
+	return x, ok
+}
+
+// IsInvalidEnumValue returns true iff err is an instance of InvalidEnumValueError.
+func IsInvalidEnumValue(err error) bool {
+	var x InvalidEnumValueError
+	return errors.As(err, &x)
+}
+
+// AsInvalidEnumValue returns the InvalidEnumValueError in err's chain, if any.
+func AsInvalidEnumValue(err error) (InvalidEnumValueError, bool) {
+	var x InvalidEnumValueError
+	ok := errors.As(err, &x)
+	return x, ok
+}
+
+// IsUnknownEnumValue returns true iff err is an instance of UnknownEnumValueError.
+func IsUnknownEnumValue(err error) bool {
+	var x UnknownEnumValueError
+	return errors.As(err, &x)
+}
+
+// AsUnknownEnumValue returns the UnknownEnumValueError in err's chain, if any.
+func AsUnknownEnumValue(err error) (UnknownEnumValueError, bool) {
+	var x UnknownEnumValueError
+	ok := errors.As(err, &x)
+	return x, ok
+}
+
+// IsInvalidBitfieldName returns true iff err is an instance of InvalidBitfieldNameError.
+func IsInvalidBitfieldName(err error) bool {
+	var x InvalidBitfieldNameError
+	return errors.As(err, &x)
+}
+
+// AsInvalidBitfieldName returns the InvalidBitfieldNameError in err's chain, if any.
+func AsInvalidBitfieldName(err error) (InvalidBitfieldNameError, bool) {
+	var x InvalidBitfieldNameError
+	ok := errors.As(err, &x)
+	return x, ok
+}
+
+// IsInvalidBitfieldIndex returns true iff err is an instance of InvalidBitfieldIndexError.
+func IsInvalidBitfieldIndex(err error) bool {
+	var x InvalidBitfieldIndexError
+	return errors.As(err, &x)
+}
+
+// AsInvalidBitfieldIndex returns the InvalidBitfieldIndexError in err's chain, if any.
+func AsInvalidBitfieldIndex(err error) (InvalidBitfieldIndexError, bool) {
+	var x InvalidBitfieldIndexError
+	ok := errors.As(err, &x)
+	return x, ok
+}
+
 // type IsNullError {{{
 
 // IsNullError indicates that a JSON null value was parsed.
@@ -23,6 +132,12 @@ func (IsNullError) Error() string {
 	return "JSON value is null"
 }
 
+// Is returns true iff target is any IsNullError, regardless of its fields.
+func (IsNullError) Is(target error) bool {
+	_, ok := target.(IsNullError)
+	return ok
+}
+
 var _ error = IsNullError{}
 
 // }}}
@@ -45,6 +160,13 @@ func (err InvalidEnumNameError) Error() string {
 	return fmt.Sprintf("invalid %s name %q; must be one of %q", err.Type, err.Name, err.Allowed)
 }
 
+// Is returns true iff target is any InvalidEnumNameError, regardless of its
+// fields.
+func (InvalidEnumNameError) Is(target error) bool {
+	_, ok := target.(InvalidEnumNameError)
+	return ok
+}
+
 var _ error = InvalidEnumNameError{}
 
 // }}}
@@ -66,10 +188,44 @@ func (err InvalidEnumValueError) Error() string {
 	return fmt.Sprintf("invalid %s value %d; must be < %d", err.Type, err.Value, err.Limit)
 }
 
+// Is returns true iff target is any InvalidEnumValueError, regardless of its
+// fields.
+func (InvalidEnumValueError) Is(target error) bool {
+	_, ok := target.(InvalidEnumValueError)
+	return ok
+}
+
 var _ error = InvalidEnumValueError{}
 
 // }}}
 
+// type UnknownEnumValueError {{{
+
+// UnknownEnumValueError indicates an enum whose numeric value is not one of
+// the known values.  It is the counterpart of InvalidEnumValueError for enum
+// types whose values need not be contiguous, such as Int8EnumType and
+// SparseEnumType.
+type UnknownEnumValueError struct {
+	Type  string
+	Value int64
+}
+
+// Error fulfills the error interface.
+func (err UnknownEnumValueError) Error() string {
+	return fmt.Sprintf("unknown %s value %d", err.Type, err.Value)
+}
+
+// Is returns true iff target is any UnknownEnumValueError, regardless of its
+// fields.
+func (UnknownEnumValueError) Is(target error) bool {
+	_, ok := target.(UnknownEnumValueError)
+	return ok
+}
+
+var _ error = UnknownEnumValueError{}
+
+// }}}
+
 // type InvalidBitfieldNameError {{{
 
 // InvalidBitfieldNameError indicates a bitfield bit whose string representation
@@ -88,10 +244,63 @@ func (err InvalidBitfieldNameError) Error() string {
 	return fmt.Sprintf("invalid %s name %q; must be one of %q", err.Type, err.Name, err.Allowed)
 }
 
+// Is returns true iff target is any InvalidBitfieldNameError, regardless of its
+// fields.
+func (InvalidBitfieldNameError) Is(target error) bool {
+	_, ok := target.(InvalidBitfieldNameError)
+	return ok
+}
+
 var _ error = InvalidBitfieldNameError{}
 
 // }}}
 
+// type MultiBitfieldParseError {{{
+
+// MultiBitfieldParseError indicates a bitfield string representation in which
+// more than one pipe-delimited item could not be recognized.  On Go 1.20 or
+// later, the individual errors can be inspected with errors.As.
+type MultiBitfieldParseError struct {
+	Type   string
+	Errors []InvalidBitfieldNameError
+}
+
+// Error fulfills the error interface.
+func (err MultiBitfieldParseError) Error() string {
+	names := make([]string, len(err.Errors))
+	for i, x := range err.Errors {
+		names[i] = x.Name
+	}
+	var allowed []string
+	if len(err.Errors) != 0 {
+		allowed = err.Errors[0].Allowed
+	}
+	if len(allowed) == 0 {
+		return fmt.Sprintf("invalid %s names %q", err.Type, names)
+	}
+	return fmt.Sprintf("invalid %s names %q; each must be one of %q", err.Type, names, allowed)
+}
+
+// Unwrap returns the individual errors, for use by errors.Is and errors.As.
+func (err MultiBitfieldParseError) Unwrap() []error {
+	out := make([]error, len(err.Errors))
+	for i, x := range err.Errors {
+		out[i] = x
+	}
+	return out
+}
+
+// Is returns true iff target is any MultiBitfieldParseError, regardless of
+// its fields.
+func (MultiBitfieldParseError) Is(target error) bool {
+	_, ok := target.(MultiBitfieldParseError)
+	return ok
+}
+
+var _ error = MultiBitfieldParseError{}
+
+// }}}
+
 // type InvalidBitfieldIndexError {{{
 
 // InvalidBitfieldIndexError indicates an enum whose numeric value is out of range.
@@ -109,6 +318,170 @@ func (err InvalidBitfieldIndexError) Error() string {
 	return fmt.Sprintf("invalid %s value %d; must be < %d", err.Type, err.Index, err.Limit)
 }
 
+// Is returns true iff target is any InvalidBitfieldIndexError, regardless of
+// its fields.
+func (InvalidBitfieldIndexError) Is(target error) bool {
+	_, ok := target.(InvalidBitfieldIndexError)
+	return ok
+}
+
 var _ error = InvalidBitfieldIndexError{}
 
 // }}}
+
+// type DuplicateNameError {{{
+
+// DuplicateNameError indicates an enum value or bitfield bit whose string
+// representation conflicts with one that is already defined.
+type DuplicateNameError struct {
+	Type string
+	Name string
+}
+
+// Error fulfills the error interface.
+func (err DuplicateNameError) Error() string {
+	return fmt.Sprintf("duplicate %s name %q", err.Type, err.Name)
+}
+
+// Is returns true iff target is any DuplicateNameError, regardless of its
+// fields.
+func (DuplicateNameError) Is(target error) bool {
+	_, ok := target.(DuplicateNameError)
+	return ok
+}
+
+var _ error = DuplicateNameError{}
+
+// }}}
+
+// type DuplicateBitfieldIndexError {{{
+
+// DuplicateBitfieldIndexError indicates a bitfield bit whose index is already
+// in use by another named bit.
+type DuplicateBitfieldIndexError struct {
+	Type  string
+	Index uint
+}
+
+// Error fulfills the error interface.
+func (err DuplicateBitfieldIndexError) Error() string {
+	return fmt.Sprintf("duplicate %s bit index %d", err.Type, err.Index)
+}
+
+// Is returns true iff target is any DuplicateBitfieldIndexError, regardless of
+// its fields.
+func (DuplicateBitfieldIndexError) Is(target error) bool {
+	_, ok := target.(DuplicateBitfieldIndexError)
+	return ok
+}
+
+var _ error = DuplicateBitfieldIndexError{}
+
+// }}}
+
+// type InputTooLargeError {{{
+
+// InputTooLargeError indicates that an input stream was longer than the
+// maximum allowed size.
+type InputTooLargeError struct {
+	Type  string
+	Limit int64
+}
+
+// Error fulfills the error interface.
+func (err InputTooLargeError) Error() string {
+	return fmt.Sprintf("input for %s exceeds %d bytes", err.Type, err.Limit)
+}
+
+// Is returns true iff target is any InputTooLargeError, regardless of its
+// fields.
+func (InputTooLargeError) Is(target error) bool {
+	_, ok := target.(InputTooLargeError)
+	return ok
+}
+
+var _ error = InputTooLargeError{}
+
+// }}}
+
+// type DeprecatedEnumValueError {{{
+
+// DeprecatedEnumValueError indicates an enum value which is valid but
+// deprecated.  It is returned only by EnumType.Validate.
+type DeprecatedEnumValueError struct {
+	Type  string
+	Value uint
+	Name  string
+}
+
+// Error fulfills the error interface.
+func (err DeprecatedEnumValueError) Error() string {
+	if err.Name == "" {
+		return fmt.Sprintf("%s value %d is deprecated", err.Type, err.Value)
+	}
+	return fmt.Sprintf("%s value %q is deprecated", err.Type, err.Name)
+}
+
+// Is returns true iff target is any DeprecatedEnumValueError, regardless of its
+// fields.
+func (DeprecatedEnumValueError) Is(target error) bool {
+	_, ok := target.(DeprecatedEnumValueError)
+	return ok
+}
+
+var _ error = DeprecatedEnumValueError{}
+
+// }}}
+
+// type StaleDiffError {{{
+
+// StaleDiffError indicates that a diff passed to EnumType.ApplyDiff or
+// BitfieldType.ApplyDiff does not match the type it is being applied to, e.g.
+// because the entry it removes or changes has already been modified.  Index is
+// the enum value or bit index of the mismatched entry.
+type StaleDiffError struct {
+	Type  string
+	Index uint
+}
+
+// Error fulfills the error interface.
+func (err StaleDiffError) Error() string {
+	return fmt.Sprintf("stale diff for %s at index %d", err.Type, err.Index)
+}
+
+// Is returns true iff target is any StaleDiffError, regardless of its fields.
+func (StaleDiffError) Is(target error) bool {
+	_, ok := target.(StaleDiffError)
+	return ok
+}
+
+var _ error = StaleDiffError{}
+
+// }}}
+
+// type InvalidIdentifierError {{{
+
+// InvalidIdentifierError indicates an enum value or bitfield bit whose name
+// cannot be converted to a valid identifier in a generated schema, e.g.
+// because the converted name is empty or begins with a digit.
+type InvalidIdentifierError struct {
+	Type       string
+	Name       string
+	Identifier string
+}
+
+// Error fulfills the error interface.
+func (err InvalidIdentifierError) Error() string {
+	return fmt.Sprintf("%s name %q converts to invalid identifier %q", err.Type, err.Name, err.Identifier)
+}
+
+// Is returns true iff target is any InvalidIdentifierError, regardless of its
+// fields.
+func (InvalidIdentifierError) Is(target error) bool {
+	_, ok := target.(InvalidIdentifierError)
+	return ok
+}
+
+var _ error = InvalidIdentifierError{}
+
+// }}}
@@ -0,0 +1,184 @@
+package enumhelper
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrors_Is(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Err      error
+		Sentinel error
+	}
+
+	allowed := []string{"red", "green"}
+	testData := []testCase{
+		{"IsNullError", IsNullError{}, ErrNullJSON},
+		{"InvalidEnumNameError", InvalidEnumNameError{Type: "Color", Name: "pink", Allowed: allowed}, ErrInvalidEnumName},
+		{"InvalidEnumValueError", InvalidEnumValueError{Type: "Color", Value: 7, Limit: 2}, ErrInvalidEnumValue},
+		{"UnknownEnumValueError", UnknownEnumValueError{Type: "Errno", Value: -7}, ErrUnknownEnumValue},
+		{"InvalidBitfieldNameError", InvalidBitfieldNameError{Type: "Perm", Name: "sticky", Allowed: allowed}, ErrInvalidBitfieldName},
+		{"InvalidBitfieldIndexError", InvalidBitfieldIndexError{Type: "Perm", Index: 70, Limit: 64}, ErrInvalidBitfieldIndex},
+		{"DuplicateNameError", DuplicateNameError{Type: "Perm", Name: "read"}, ErrDuplicateName},
+		{"DuplicateBitfieldIndexError", DuplicateBitfieldIndexError{Type: "Perm", Index: 3}, ErrDuplicateBitfieldIndex},
+		{"InputTooLargeError", InputTooLargeError{Type: "Color", Limit: 16}, ErrInputTooLarge},
+		{"DeprecatedEnumValueError", DeprecatedEnumValueError{Type: "Color", Value: 1, Name: "green"}, ErrDeprecatedEnumValue},
+		{"MultiBitfieldParseError", MultiBitfieldParseError{Type: "Perm"}, ErrMultiBitfieldParse},
+		{"StaleDiffError", StaleDiffError{Type: "Perm", Index: 2}, ErrStaleDiff},
+		{"InvalidIdentifierError", InvalidIdentifierError{Type: "Perm", Name: "9lives", Identifier: "9lives"}, ErrInvalidIdentifier},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			if !errors.Is(row.Err, row.Sentinel) {
+				t.Errorf("errors.Is(%#v, %#v) = false", row.Err, row.Sentinel)
+			}
+			wrapped := fmt.Errorf("wrapped: %w", row.Err)
+			if !errors.Is(wrapped, row.Sentinel) {
+				t.Errorf("errors.Is(wrapped %#v, %#v) = false", row.Err, row.Sentinel)
+			}
+			for _, other := range testData {
+				if other.Name != row.Name && errors.Is(row.Err, other.Sentinel) {
+					t.Errorf("errors.Is(%#v, %#v) = true", row.Err, other.Sentinel)
+				}
+			}
+		})
+	}
+}
+
+func TestErrors_As(t *testing.T) {
+	type testCase struct {
+		Name string
+		Err  error
+		Is   func(error) bool
+		As   func(error) (error, bool)
+	}
+
+	allowed := []string{"red", "green"}
+	testData := []testCase{
+		{
+			Name: "IsNullError",
+			Err:  IsNullError{},
+			Is:   IsNull,
+		},
+		{
+			Name: "InvalidEnumNameError",
+			Err:  InvalidEnumNameError{Type: "Color", Name: "pink", Allowed: allowed},
+			Is:   IsInvalidEnumName,
+			As: func(err error) (error, bool) {
+				x, ok := AsInvalidEnumName(err)
+				return x, ok
+			},
+		},
+		{
+			Name: "InvalidEnumValueError",
+			Err:  InvalidEnumValueError{Type: "Color", Value: 7, Limit: 2},
+			Is:   IsInvalidEnumValue,
+			As: func(err error) (error, bool) {
+				x, ok := AsInvalidEnumValue(err)
+				return x, ok
+			},
+		},
+		{
+			Name: "UnknownEnumValueError",
+			Err:  UnknownEnumValueError{Type: "Errno", Value: -7},
+			Is:   IsUnknownEnumValue,
+			As: func(err error) (error, bool) {
+				x, ok := AsUnknownEnumValue(err)
+				return x, ok
+			},
+		},
+		{
+			Name: "InvalidBitfieldNameError",
+			Err:  InvalidBitfieldNameError{Type: "Perm", Name: "sticky", Allowed: allowed},
+			Is:   IsInvalidBitfieldName,
+			As: func(err error) (error, bool) {
+				x, ok := AsInvalidBitfieldName(err)
+				return x, ok
+			},
+		},
+		{
+			Name: "InvalidBitfieldIndexError",
+			Err:  InvalidBitfieldIndexError{Type: "Perm", Index: 70, Limit: 64},
+			Is:   IsInvalidBitfieldIndex,
+			As: func(err error) (error, bool) {
+				x, ok := AsInvalidBitfieldIndex(err)
+				return x, ok
+			},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			levels := []error{
+				row.Err,
+				fmt.Errorf("one: %w", row.Err),
+				fmt.Errorf("two: %w", fmt.Errorf("one: %w", row.Err)),
+			}
+			for depth, err := range levels {
+				if !row.Is(err) {
+					t.Errorf("depth %d: Is(%v) = false", depth, err)
+				}
+				if row.As != nil {
+					x, ok := row.As(err)
+					if !ok || fmt.Sprintf("%#v", x) != fmt.Sprintf("%#v", row.Err) {
+						t.Errorf("depth %d: As(%v): expected %#v, true; got %#v, %v", depth, err, row.Err, x, ok)
+					}
+				}
+				for _, other := range testData {
+					if other.Name == row.Name {
+						continue
+					}
+					if other.Is(err) {
+						t.Errorf("depth %d: Is for %s matched %v", depth, other.Name, err)
+					}
+					if other.As != nil {
+						if _, ok := other.As(err); ok {
+							t.Errorf("depth %d: As for %s matched %v", depth, other.Name, err)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMultiBitfieldParseError_Unwrap(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	_, err := perm.FromString("sticky|read|setuid")
+	if !errors.Is(err, ErrMultiBitfieldParse) {
+		t.Fatalf("expected %T, got %#v", ErrMultiBitfieldParse, err)
+	}
+	if !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("errors.Is(%v, ErrInvalidBitfieldName) = false", err)
+	}
+
+	var first InvalidBitfieldNameError
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &first) {
+		t.Fatalf("errors.As(%v) = false", err)
+	}
+	if first.Name != "sticky" {
+		t.Errorf("errors.As: expected Name %q, got %q", "sticky", first.Name)
+	}
+
+	var names []string
+	for _, x := range err.(MultiBitfieldParseError).Unwrap() {
+		item, ok := AsInvalidBitfieldName(x)
+		if !ok {
+			t.Fatalf("Unwrap: expected InvalidBitfieldNameError, got %#v", x)
+		}
+		names = append(names, item.Name)
+	}
+	if len(names) != 2 || names[0] != "sticky" || names[1] != "setuid" {
+		t.Errorf("Unwrap: expected names [sticky setuid], got %q", names)
+	}
+
+	// A single bad item is reported on its own.
+	_, err = perm.FromString("read|sticky")
+	if errors.Is(err, ErrMultiBitfieldParse) || !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("expected a lone InvalidBitfieldNameError, got %#v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package enumhelper_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chronos-tachyon/enumhelper"
+)
+
+// Color is an enum type, as a code generator might write it.
+type Color uint
+
+const (
+	ColorRed Color = iota
+	ColorGreen
+	ColorBlue
+)
+
+var colorType = enumhelper.MakeEnumType("Color", []enumhelper.EnumData{
+	{GoName: "ColorRed", Name: "red"},
+	{GoName: "ColorGreen", Name: "green"},
+	{GoName: "ColorBlue", Name: "blue", Aliases: []string{"azure"}},
+})
+
+// String fulfills fmt.Stringer.
+func (c Color) String() string {
+	return colorType.ToString(uint(c))
+}
+
+// MarshalJSON fulfills json.Marshaler.
+func (c Color) MarshalJSON() ([]byte, error) {
+	return colorType.ToJSON(uint(c))
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (c *Color) UnmarshalJSON(raw []byte) error {
+	value, err := colorType.FromJSON(raw)
+	if err != nil {
+		return err
+	}
+	*c = Color(value)
+	return nil
+}
+
+var (
+	_ json.Marshaler   = Color(0)
+	_ json.Unmarshaler = (*Color)(nil)
+)
+
+// This example shows how a generated enum type implements json.Marshaler and
+// json.Unmarshaler with EnumType.ToJSON and EnumType.FromJSON.
+func ExampleEnumType_ToJSON() {
+	type Pixel struct {
+		X, Y  int
+		Color Color
+	}
+
+	raw, err := json.Marshal(Pixel{X: 1, Y: 2, Color: ColorBlue})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(raw))
+
+	var p Pixel
+	if err := json.Unmarshal([]byte(`{"X":3,"Y":4,"Color":"azure"}`), &p); err != nil {
+		panic(err)
+	}
+	fmt.Println(p.X, p.Y, p.Color)
+
+	err = json.Unmarshal([]byte(`{"Color":"pink"}`), &p)
+	fmt.Println(err)
+
+	// Output:
+	// {"X":1,"Y":2,"Color":"blue"}
+	// 3 4 blue
+	// invalid Color name "pink"; must be one of ["red" "green" "blue"]
+}
+
+// This example shows that numeric JSON values are accepted too.
+func ExampleEnumType_FromJSON() {
+	var colors []Color
+	if err := json.Unmarshal([]byte(`["red", 1, "BLUE"]`), &colors); err != nil {
+		panic(err)
+	}
+	fmt.Println(colors)
+
+	// Output:
+	// [red green blue]
+}
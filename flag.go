@@ -0,0 +1,98 @@
+package enumhelper
+
+import (
+	"flag"
+	"strings"
+)
+
+// type enumFlag {{{
+
+type enumFlag struct {
+	typ EnumType
+	ptr *uint
+}
+
+// NewFlag returns a flag.Value which stores a value of this enum type in
+// *ptr.  Use FlagUsage to describe the accepted names.
+func (enum EnumType) NewFlag(ptr *uint) flag.Value {
+	return &enumFlag{typ: enum, ptr: ptr}
+}
+
+// FlagUsage returns a usage string listing the names accepted by the
+// flag.Value returned from NewFlag.
+func (enum EnumType) FlagUsage() string {
+	return "one of: " + strings.Join(enum.Names, ", ")
+}
+
+func (f *enumFlag) String() string {
+	if f == nil || f.ptr == nil {
+		return ""
+	}
+	return f.typ.ToString(*f.ptr)
+}
+
+func (f *enumFlag) Set(str string) error {
+	value, err := f.typ.Parse(str)
+	if err != nil {
+		return err
+	}
+	*f.ptr = value
+	return nil
+}
+
+func (f *enumFlag) Get() interface{} {
+	return *f.ptr
+}
+
+var _ flag.Getter = (*enumFlag)(nil)
+
+// }}}
+
+// type bitfieldFlag {{{
+
+type bitfieldFlag struct {
+	typ BitfieldType
+	ptr *uint64
+}
+
+// NewFlag returns a flag.Value which stores a value of this bitfield type in
+// *ptr.  Each use of the flag adds the named bits to *ptr, so that
+// "-flag=read -flag=write" is equivalent to "-flag=read|write".  Use
+// FlagUsage to describe the accepted names.
+func (bitfield BitfieldType) NewFlag(ptr *uint64) flag.Value {
+	return &bitfieldFlag{typ: bitfield, ptr: ptr}
+}
+
+// FlagUsage returns a usage string listing the names accepted by the
+// flag.Value returned from NewFlag.
+func (bitfield BitfieldType) FlagUsage() string {
+	return "'|'-separated list of: " + strings.Join(bitfield.Names, ", ")
+}
+
+func (f *bitfieldFlag) String() string {
+	if f == nil || f.ptr == nil {
+		return ""
+	}
+	return f.typ.ToString(*f.ptr)
+}
+
+func (f *bitfieldFlag) Set(str string) error {
+	value, err := f.typ.parse(str)
+	if err != nil {
+		return err
+	}
+	value, err = f.typ.validate(*f.ptr | value)
+	if err != nil {
+		return err
+	}
+	*f.ptr = value
+	return nil
+}
+
+func (f *bitfieldFlag) Get() interface{} {
+	return *f.ptr
+}
+
+var _ flag.Getter = (*bitfieldFlag)(nil)
+
+// }}}
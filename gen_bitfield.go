@@ -0,0 +1,560 @@
+//go:build ignore
+// +build ignore
+
+// gen_bitfield generates bitfield8.go, bitfield16.go, and bitfield32.go from a
+// single template.  Run it with "go generate".
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"text/template"
+)
+
+type params struct {
+	Bits     uint
+	MaxIndex uint
+}
+
+func main() {
+	tmpl := template.Must(template.New("bitfield").Parse(bitfieldTemplate))
+	for _, bits := range []uint{8, 16, 32} {
+		if err := generate(tmpl, bits); err != nil {
+			fmt.Fprintf(os.Stderr, "gen_bitfield: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+func generate(tmpl *template.Template, bits uint) error {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_bitfield.go; DO NOT EDIT.\n\n")
+	if err := tmpl.Execute(&buf, params{Bits: bits, MaxIndex: bits - 1}); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf("bitfield%d.go", bits), src, 0o666)
+}
+
+const bitfieldTemplate = `package enumhelper
+
+import (
+	"database/sql/driver"
+	"flag"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AnnotatedBitfieldData{{.Bits}} is like AnnotatedBitfieldData, but for a bitfield
+// type whose values are stored as uint{{.Bits}}.
+type AnnotatedBitfieldData{{.Bits}} struct {
+	BitfieldData
+
+	// Index is the index of this bit; always between 0 and {{.MaxIndex}}.
+	Index uint
+
+	// Bit is the value of this bit; always equal to (1 << Index).
+	Bit uint{{.Bits}}
+}
+
+// BitfieldType{{.Bits}} is like BitfieldType, but for bitfields whose values are
+// stored as uint{{.Bits}}.  Its methods mirror those of BitfieldType, with bit values
+// of type uint{{.Bits}}.  The methods which derive new types (TruncateTo, AddBit,
+// ExtendWith, Intersection, Diff, and ApplyDiff), which register aliases
+// (RegisterAlias, DeregisterAlias, and Freeze), or which return types tied to
+// BitfieldType (Wrap, WithRWMutex, MakeTestTable, ToSlogAttr, and ToSlogGroup)
+// are omitted; use Widen to access those.
+type BitfieldType{{.Bits}} struct {
+	// Type gives the Go name for this bitfield type.
+	Type string
+
+	// Data lists the data for all {{.Bits}} bits.
+	Data []*AnnotatedBitfieldData{{.Bits}}
+
+	// Names holds some valid example names for the bitfield bits, if any.
+	Names []string
+
+	// ByName maps valid names to the data for the corresponding bit.  It is a
+	// snapshot taken when this type was made: aliases registered later through
+	// Widen are accepted by FromString, but do not appear here.
+	ByName map[string]*AnnotatedBitfieldData{{.Bits}}
+
+	impl BitfieldType
+}
+
+// MakeBitfieldType{{.Bits}} initializes and returns a BitfieldType{{.Bits}}.  Entries in in
+// beyond index {{.MaxIndex}} are ignored.
+func MakeBitfieldType{{.Bits}}(typeName string, in []BitfieldData) BitfieldType{{.Bits}} {
+	return makeBitfieldType{{.Bits}}(makeBitfieldType(typeName, in, {{.Bits}}))
+}
+
+func makeBitfieldType{{.Bits}}(impl BitfieldType) BitfieldType{{.Bits}} {
+	out := BitfieldType{{.Bits}}{
+		Type:   impl.Type,
+		Data:   make([]*AnnotatedBitfieldData{{.Bits}}, len(impl.Data)),
+		Names:  impl.Names,
+		ByName: make(map[string]*AnnotatedBitfieldData{{.Bits}}, len(impl.ByName)),
+		impl:   impl,
+	}
+
+	for index, data := range impl.Data {
+		out.Data[index] = &AnnotatedBitfieldData{{.Bits}}{
+			BitfieldData: data.BitfieldData,
+			Index:        data.Index,
+			Bit:          uint{{.Bits}}(data.Bit),
+		}
+	}
+
+	for name, data := range impl.ByName {
+		out.ByName[name] = out.Data[data.Index]
+	}
+	return out
+}
+
+// Widen returns the BitfieldType which backs this bitfield type.  Its values
+// are uint64, but never have bits set beyond bit {{.MaxIndex}}.
+func (bitfield BitfieldType{{.Bits}}) Widen() BitfieldType {
+	return bitfield.impl
+}
+
+// Get returns bitfield.Data[index] or panics with InvalidBitfieldIndexError.
+func (bitfield BitfieldType{{.Bits}}) Get(index uint) AnnotatedBitfieldData{{.Bits}} {
+	bitfield.impl.Get(index)
+	return *bitfield.Data[index]
+}
+
+// ContainsBit returns true iff index is less than {{.Bits}} and the bit at that
+// index has a name.
+func (bitfield BitfieldType{{.Bits}}) ContainsBit(index uint) bool {
+	return bitfield.impl.ContainsBit(index)
+}
+
+// ForEach iterates over bitfield.Data with the given callback function.
+func (bitfield BitfieldType{{.Bits}}) ForEach(fn func(data AnnotatedBitfieldData{{.Bits}})) {
+	for _, data := range bitfield.Data {
+		fn(*data)
+	}
+}
+
+// ToGoString generates a Go string representation for the given bitfield value.
+func (bitfield BitfieldType{{.Bits}}) ToGoString(value uint{{.Bits}}) string {
+	return bitfield.impl.ToGoString(uint64(value))
+}
+
+// GoString is an alias for ToGoString.
+func (bitfield BitfieldType{{.Bits}}) GoString(value uint{{.Bits}}) string {
+	return bitfield.ToGoString(value)
+}
+
+// ToString generates a string representation for the given bitfield value.
+func (bitfield BitfieldType{{.Bits}}) ToString(value uint{{.Bits}}) string {
+	return bitfield.impl.ToString(uint64(value))
+}
+
+// String is an alias for ToString.
+func (bitfield BitfieldType{{.Bits}}) String(value uint{{.Bits}}) string {
+	return bitfield.ToString(value)
+}
+
+// ToJSON marshals this bitfield value to JSON, using the format selected by
+// WithEncodedAs.
+func (bitfield BitfieldType{{.Bits}}) ToJSON(value uint{{.Bits}}) ([]byte, error) {
+	return bitfield.impl.ToJSON(uint64(value))
+}
+
+// ToJSONArray marshals this bitfield value to a JSON array of bit names.
+func (bitfield BitfieldType{{.Bits}}) ToJSONArray(value uint{{.Bits}}) ([]byte, error) {
+	return bitfield.impl.ToJSONArray(uint64(value))
+}
+
+// WithEncodedAs returns a copy of this bitfield type whose ToJSON method uses
+// the given format.  See BitfieldType.WithEncodedAs.
+func (bitfield BitfieldType{{.Bits}}) WithEncodedAs(format string) BitfieldType{{.Bits}} {
+	out := bitfield
+	out.impl = bitfield.impl.WithEncodedAs(format)
+	return out
+}
+
+// WithUnknownBitBehavior returns a copy of this bitfield type whose FromString
+// and FromJSON methods handle unknown bits according to mode.
+func (bitfield BitfieldType{{.Bits}}) WithUnknownBitBehavior(mode UnknownBitMode) BitfieldType{{.Bits}} {
+	out := bitfield
+	out.impl = bitfield.impl.WithUnknownBitBehavior(mode)
+	return out
+}
+
+// WithMaxReadSize returns a copy of this bitfield type whose ParseFromReader
+// method reads at most n bytes.  If n <= 0, DefaultMaxReadSize is used.
+func (bitfield BitfieldType{{.Bits}}) WithMaxReadSize(n int64) BitfieldType{{.Bits}} {
+	out := bitfield
+	out.impl = bitfield.impl.WithMaxReadSize(n)
+	return out
+}
+
+// FromString parses the string representation of a bitfield value.  Returns
+// InvalidBitfieldNameError if the string cannot be parsed, including if it
+// holds a number which does not fit in uint{{.Bits}}.
+func (bitfield BitfieldType{{.Bits}}) FromString(str string) (uint{{.Bits}}, error) {
+	u64, err := bitfield.impl.FromString(str)
+	return uint{{.Bits}}(u64), err
+}
+
+// FromStringOrZero is like FromString, but returns 0 if the string cannot be
+// parsed.
+func (bitfield BitfieldType{{.Bits}}) FromStringOrZero(str string) uint{{.Bits}} {
+	return uint{{.Bits}}(bitfield.impl.FromStringOrZero(str))
+}
+
+// FromStringOrMax is like FromString, but returns ^uint{{.Bits}}(0) if the string
+// cannot be parsed.
+func (bitfield BitfieldType{{.Bits}}) FromStringOrMax(str string) uint{{.Bits}} {
+	return uint{{.Bits}}(bitfield.impl.FromStringOrMax(str))
+}
+
+// FromJSON unmarshals a bitfield value from JSON.  Returns IsNullError or
+// InvalidBitfieldNameError if a JSON value was parsed but could not be
+// unmarshaled as an bitfield value.
+func (bitfield BitfieldType{{.Bits}}) FromJSON(raw []byte) (uint{{.Bits}}, error) {
+	u64, err := bitfield.impl.FromJSON(raw)
+	return uint{{.Bits}}(u64), err
+}
+
+// FromJSONArray is equivalent to FromJSON.
+func (bitfield BitfieldType{{.Bits}}) FromJSONArray(raw []byte) (uint{{.Bits}}, error) {
+	return bitfield.FromJSON(raw)
+}
+
+// MustFromJSON is like FromJSON, but panics with the error if the JSON value
+// cannot be unmarshaled.
+func (bitfield BitfieldType{{.Bits}}) MustFromJSON(raw []byte) uint{{.Bits}} {
+	return uint{{.Bits}}(bitfield.impl.MustFromJSON(raw))
+}
+
+// ParseFromReader reads all bytes from r, trims surrounding whitespace, and
+// parses the result with FromString.  Returns InputTooLargeError if r holds
+// more than the limit set by WithMaxReadSize.
+func (bitfield BitfieldType{{.Bits}}) ParseFromReader(r io.Reader) (uint{{.Bits}}, error) {
+	u64, err := bitfield.impl.ParseFromReader(r)
+	return uint{{.Bits}}(u64), err
+}
+
+// MakeConstMap returns a map from the GoName of each bit to its value.  Bits
+// without a GoName are omitted.
+func (bitfield BitfieldType{{.Bits}}) MakeConstMap() map[string]uint{{.Bits}} {
+	out := make(map[string]uint{{.Bits}}, len(bitfield.Names))
+	bitfield.ForEach(func(data AnnotatedBitfieldData{{.Bits}}) {
+		if data.GoName != "" {
+			out[data.GoName] = data.Bit
+		}
+	})
+	return out
+}
+
+// MakeReverseMap returns a map from the value of each named bit to its
+// canonical string representation.
+func (bitfield BitfieldType{{.Bits}}) MakeReverseMap() map[uint{{.Bits}}]string {
+	out := make(map[uint{{.Bits}}]string, len(bitfield.Names))
+	bitfield.ForEach(func(data AnnotatedBitfieldData{{.Bits}}) {
+		if name := data.canonicalName(); name != "" {
+			out[data.Bit] = name
+		}
+	})
+	return out
+}
+
+// DumpBits returns a {{.Bits}}-character string describing the given bitfield value,
+// one character per bit, with bit {{.MaxIndex}} first and bit 0 last.  See
+// BitfieldType.DumpBits.
+func (bitfield BitfieldType{{.Bits}}) DumpBits(value uint{{.Bits}}) string {
+	return bitfield.impl.DumpBits(uint64(value))
+}
+
+// Traverse calls fn for each bit in order of increasing index, starting with
+// startIndex, until fn returns false.  Panics with InvalidBitfieldIndexError
+// if startIndex is out of range.
+func (bitfield BitfieldType{{.Bits}}) Traverse(startIndex uint, fn func(index uint, data AnnotatedBitfieldData{{.Bits}}) bool) {
+	bitfield.Get(startIndex)
+	for _, data := range bitfield.Data[startIndex:] {
+		if !fn(data.Index, *data) {
+			return
+		}
+	}
+}
+
+// DescribeBit returns the Description of the bit at the given index, or its
+// canonical name if it has no Description.
+func (bitfield BitfieldType{{.Bits}}) DescribeBit(index uint) string {
+	return bitfield.impl.DescribeBit(index)
+}
+
+// ForEachInMask calls fn for each named bit which is set in mask, in order of
+// increasing index.
+func (bitfield BitfieldType{{.Bits}}) ForEachInMask(mask uint{{.Bits}}, fn func(data AnnotatedBitfieldData{{.Bits}})) {
+	bitfield.impl.ForEachInMask(uint64(mask), func(data AnnotatedBitfieldData) {
+		fn(*bitfield.Data[data.Index])
+	})
+}
+
+// AllAliasesForBit returns every string which FromString resolves to the bit
+// at the given index.  See BitfieldType.AllAliasesForBit.
+func (bitfield BitfieldType{{.Bits}}) AllAliasesForBit(index uint) []string {
+	return bitfield.impl.AllAliasesForBit(index)
+}
+
+// SetBits returns the data for each named bit which is set in value, in order
+// of increasing index.
+func (bitfield BitfieldType{{.Bits}}) SetBits(value uint{{.Bits}}) []AnnotatedBitfieldData{{.Bits}} {
+	var out []AnnotatedBitfieldData{{.Bits}}
+	bitfield.ForEachInMask(value, func(data AnnotatedBitfieldData{{.Bits}}) {
+		out = append(out, data)
+	})
+	return out
+}
+
+// WithValidationHook returns a copy of this bitfield type whose parsing
+// methods call fn with each value that they resolve.  See
+// BitfieldType.WithValidationHook.
+func (bitfield BitfieldType{{.Bits}}) WithValidationHook(fn func(value uint{{.Bits}}) error) BitfieldType{{.Bits}} {
+	out := bitfield
+	out.impl = bitfield.impl.WithValidationHook(func(u64 uint64) error {
+		return fn(uint{{.Bits}}(u64))
+	})
+	return out
+}
+
+// KnownBits returns the OR of all bits which have a name.
+func (bitfield BitfieldType{{.Bits}}) KnownBits() uint{{.Bits}} {
+	return uint{{.Bits}}(bitfield.impl.KnownBits())
+}
+
+// UnknownBits returns the bits which are set in value but have no name.
+func (bitfield BitfieldType{{.Bits}}) UnknownBits(value uint{{.Bits}}) uint{{.Bits}} {
+	return uint{{.Bits}}(bitfield.impl.UnknownBits(uint64(value)))
+}
+
+// IsFullyKnown returns true iff every bit which is set in value has a name.
+func (bitfield BitfieldType{{.Bits}}) IsFullyKnown(value uint{{.Bits}}) bool {
+	return bitfield.impl.IsFullyKnown(uint64(value))
+}
+
+// PopCount returns the number of named bits which are set in value.
+func (bitfield BitfieldType{{.Bits}}) PopCount(value uint{{.Bits}}) int {
+	return bitfield.impl.PopCount(uint64(value))
+}
+
+// MustFromString is like FromString, but panics with the error if the string
+// cannot be parsed.
+func (bitfield BitfieldType{{.Bits}}) MustFromString(str string) uint{{.Bits}} {
+	return uint{{.Bits}}(bitfield.impl.MustFromString(str))
+}
+
+// MakeResolver returns a function which parses a string with FromString,
+// returning false instead of an error if the string cannot be parsed.
+func (bitfield BitfieldType{{.Bits}}) MakeResolver() func(string) (uint{{.Bits}}, bool) {
+	resolve := bitfield.impl.MakeResolver()
+	return func(str string) (uint{{.Bits}}, bool) {
+		u64, ok := resolve(str)
+		return uint{{.Bits}}(u64), ok
+	}
+}
+
+// MakePredicate returns a function which returns true iff every bit in mask
+// is set in its argument.
+func (bitfield BitfieldType{{.Bits}}) MakePredicate(mask uint{{.Bits}}) func(uint{{.Bits}}) bool {
+	return func(value uint{{.Bits}}) bool {
+		return (value & mask) == mask
+	}
+}
+
+// MakeAnyPredicate returns a function which returns true iff at least one bit
+// in mask is set in its argument.
+func (bitfield BitfieldType{{.Bits}}) MakeAnyPredicate(mask uint{{.Bits}}) func(uint{{.Bits}}) bool {
+	return func(value uint{{.Bits}}) bool {
+		return (value & mask) != 0
+	}
+}
+
+// Set returns value with the given bit set.  Panics with
+// InvalidBitfieldIndexError if bit is not a single named bit.
+func (bitfield BitfieldType{{.Bits}}) Set(value, bit uint{{.Bits}}) uint{{.Bits}} {
+	return uint{{.Bits}}(bitfield.impl.Set(uint64(value), uint64(bit)))
+}
+
+// Clear returns value with the given bit cleared.  Panics with
+// InvalidBitfieldIndexError if bit is not a single named bit.
+func (bitfield BitfieldType{{.Bits}}) Clear(value, bit uint{{.Bits}}) uint{{.Bits}} {
+	return uint{{.Bits}}(bitfield.impl.Clear(uint64(value), uint64(bit)))
+}
+
+// Toggle returns value with the given bit flipped.  Panics with
+// InvalidBitfieldIndexError if bit is not a single named bit.
+func (bitfield BitfieldType{{.Bits}}) Toggle(value, bit uint{{.Bits}}) uint{{.Bits}} {
+	return uint{{.Bits}}(bitfield.impl.Toggle(uint64(value), uint64(bit)))
+}
+
+// HasAll returns true iff every bit in mask is set in value.  Unlike
+// MakePredicate, HasAll returns false if mask is zero.
+func (bitfield BitfieldType{{.Bits}}) HasAll(value, mask uint{{.Bits}}) bool {
+	return bitfield.impl.HasAll(uint64(value), uint64(mask))
+}
+
+// HasAny returns true iff at least one bit in mask is set in value.  HasAny
+// returns false if mask is zero.
+func (bitfield BitfieldType{{.Bits}}) HasAny(value, mask uint{{.Bits}}) bool {
+	return bitfield.impl.HasAny(uint64(value), uint64(mask))
+}
+
+// MaskFor returns the OR of the bits with the given names.  See
+// BitfieldType.MaskFor.
+func (bitfield BitfieldType{{.Bits}}) MaskFor(names ...string) (uint{{.Bits}}, error) {
+	u64, err := bitfield.impl.MaskFor(names...)
+	return uint{{.Bits}}(u64), err
+}
+
+type bitfieldFlag{{.Bits}} struct {
+	typ BitfieldType
+	ptr *uint{{.Bits}}
+}
+
+// NewFlag returns a flag.Value which stores a value of this bitfield type in
+// *ptr.  As with BitfieldType.NewFlag, each use of the flag adds the named
+// bits to *ptr.
+func (bitfield BitfieldType{{.Bits}}) NewFlag(ptr *uint{{.Bits}}) flag.Value {
+	return &bitfieldFlag{{.Bits}}{typ: bitfield.impl, ptr: ptr}
+}
+
+// FlagUsage returns a usage string listing the names accepted by the
+// flag.Value returned from NewFlag.
+func (bitfield BitfieldType{{.Bits}}) FlagUsage() string {
+	return bitfield.impl.FlagUsage()
+}
+
+func (f *bitfieldFlag{{.Bits}}) String() string {
+	if f == nil || f.ptr == nil {
+		return ""
+	}
+	return f.typ.ToString(uint64(*f.ptr))
+}
+
+func (f *bitfieldFlag{{.Bits}}) Set(str string) error {
+	value, err := f.typ.parse(str)
+	if err != nil {
+		return err
+	}
+	value, err = f.typ.validate(uint64(*f.ptr) | value)
+	if err != nil {
+		return err
+	}
+	*f.ptr = uint{{.Bits}}(value)
+	return nil
+}
+
+func (f *bitfieldFlag{{.Bits}}) Get() interface{} {
+	return *f.ptr
+}
+
+var _ flag.Getter = (*bitfieldFlag{{.Bits}})(nil)
+
+// ScanBitfield converts a value read from a database column into a bitfield
+// value, for use in implementing sql.Scanner.  See
+// BitfieldType.ScanBitfield.
+func (bitfield BitfieldType{{.Bits}}) ScanBitfield(src interface{}, dst *uint{{.Bits}}) error {
+	var u64 uint64
+	if err := bitfield.impl.ScanBitfield(src, &u64); err != nil {
+		return err
+	}
+	*dst = uint{{.Bits}}(u64)
+	return nil
+}
+
+// ValueBitfield converts a bitfield value into an int64 for storage in a
+// database column, for use in implementing driver.Valuer.
+func (bitfield BitfieldType{{.Bits}}) ValueBitfield(value uint{{.Bits}}) (driver.Value, error) {
+	return bitfield.impl.ValueBitfield(uint64(value))
+}
+
+// ToYAML marshals this bitfield value to YAML, for use in implementing
+// yaml.Marshaler.  See BitfieldType.ToYAML.
+func (bitfield BitfieldType{{.Bits}}) ToYAML(value uint{{.Bits}}) (interface{}, error) {
+	return bitfield.impl.ToYAML(uint64(value))
+}
+
+// FromYAML unmarshals a bitfield value from YAML, for use in implementing
+// yaml.Unmarshaler.  See BitfieldType.FromYAML.
+func (bitfield BitfieldType{{.Bits}}) FromYAML(node *yaml.Node) (uint{{.Bits}}, error) {
+	u64, err := bitfield.impl.FromYAML(node)
+	return uint{{.Bits}}(u64), err
+}
+
+// MarshalNDJSON writes the given bitfield values to w as newline-delimited
+// JSON, one value per line, in the format produced by ToJSON.
+func (bitfield BitfieldType{{.Bits}}) MarshalNDJSON(values []uint{{.Bits}}, w io.Writer) error {
+	wide := make([]uint64, len(values))
+	for i, value := range values {
+		wide[i] = uint64(value)
+	}
+	return bitfield.impl.MarshalNDJSON(wide, w)
+}
+
+// UnmarshalNDJSON reads newline-delimited JSON from r, as written by
+// MarshalNDJSON, and parses each line with FromJSON.
+func (bitfield BitfieldType{{.Bits}}) UnmarshalNDJSON(r io.Reader) ([]uint{{.Bits}}, error) {
+	wide, err := bitfield.impl.UnmarshalNDJSON(r)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]uint{{.Bits}}, len(wide))
+	for i, u64 := range wide {
+		out[i] = uint{{.Bits}}(u64)
+	}
+	return out, nil
+}
+
+// WriteAvroSchema writes an Apache Avro JSON schema describing this bitfield
+// type to w.  See BitfieldType.WriteAvroSchema.
+func (bitfield BitfieldType{{.Bits}}) WriteAvroSchema(w io.Writer) error {
+	return bitfield.impl.WriteAvroSchema(w)
+}
+
+// MarshalCUE returns a CUE definition for this bitfield type.  See
+// BitfieldType.MarshalCUE.
+func (bitfield BitfieldType{{.Bits}}) MarshalCUE(typeName string) string {
+	return bitfield.impl.MarshalCUE(typeName)
+}
+
+// WriteGraphQLEnum writes a GraphQL object type describing this bitfield
+// type to w.  See BitfieldType.WriteGraphQLEnum.
+func (bitfield BitfieldType{{.Bits}}) WriteGraphQLEnum(w io.Writer) error {
+	return bitfield.impl.WriteGraphQLEnum(w)
+}
+
+// WriteOpenAPIComponent writes a YAML fragment describing this bitfield type
+// to w.  See BitfieldType.WriteOpenAPIComponent.
+func (bitfield BitfieldType{{.Bits}}) WriteOpenAPIComponent(w io.Writer) error {
+	return bitfield.impl.WriteOpenAPIComponent(w)
+}
+
+// WriteTypeScript writes TypeScript declarations for this bitfield type to w.
+// See BitfieldType.WriteTypeScript.
+func (bitfield BitfieldType{{.Bits}}) WriteTypeScript(w io.Writer) error {
+	return bitfield.impl.WriteTypeScript(w)
+}
+
+// ToProtoDescriptor returns a description of this bitfield type as a protobuf
+// message.  See BitfieldType.ToProtoDescriptor.
+func (bitfield BitfieldType{{.Bits}}) ToProtoDescriptor() (ProtoMessageDescriptor, error) {
+	return bitfield.impl.ToProtoDescriptor()
+}
+
+// WriteProto writes a proto3 schema describing this bitfield type to w.  See
+// BitfieldType.WriteProto.
+func (bitfield BitfieldType{{.Bits}}) WriteProto(w io.Writer, packageName string) error {
+	return bitfield.impl.WriteProto(w, packageName)
+}
+`
@@ -2,4 +2,4 @@ module github.com/chronos-tachyon/enumhelper
 
 go 1.16
 
-require github.com/hashicorp/go-multierror v1.1.1
+require gopkg.in/yaml.v3 v3.0.1
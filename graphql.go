@@ -0,0 +1,57 @@
+package enumhelper
+
+import (
+	"bytes"
+	"io"
+)
+
+// WriteGraphQLEnum writes a GraphQL enum type describing this enum type.  Each
+// enum value is named by its GoName (or Name, if GoName is empty) in
+// UPPER_SNAKE_CASE, as for WriteProto.  Returns InvalidIdentifierError if a
+// value's name does not convert to a valid GraphQL name, or DuplicateNameError
+// if two values' names convert to the same GraphQL name; nothing is written in
+// either case.
+func (enum EnumType) WriteGraphQLEnum(w io.Writer) error {
+	seen := make(map[string]struct{}, len(enum.Data))
+	for _, ptr := range enum.Data {
+		if err := checkIdentifier(enum.Type, ptr.canonicalName(), protoEnumValueName(*ptr), seen); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("enum ")
+	buf.WriteString(enum.Type)
+	buf.WriteString(" {\n")
+	for _, ptr := range enum.Data {
+		buf.WriteString("  ")
+		buf.WriteString(protoEnumValueName(*ptr))
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteGraphQLEnum writes a GraphQL object type describing this bitfield
+// type, with one Boolean field per named bit.
+func (bitfield BitfieldType) WriteGraphQLEnum(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString("type ")
+	buf.WriteString(bitfield.Type)
+	buf.WriteString(" {\n")
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		name := data.canonicalName()
+		if name == "" {
+			return
+		}
+		buf.WriteString("  ")
+		buf.WriteString(snakeCase(name))
+		buf.WriteString(": Boolean\n")
+	})
+	buf.WriteString("}\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
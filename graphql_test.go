@@ -0,0 +1,68 @@
+package enumhelper
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	graphQLOpenRE  = regexp.MustCompile(`^(type|enum) ([_A-Za-z][_0-9A-Za-z]*) \{$`)
+	graphQLFieldRE = regexp.MustCompile(`^  ([_A-Za-z][_0-9A-Za-z]*): Boolean$`)
+	graphQLValueRE = regexp.MustCompile(`^  ([_A-Za-z][_0-9A-Za-z]*)$`)
+)
+
+// parseGraphQL parses a single GraphQL type or enum definition as written by
+// WriteGraphQLEnum and returns its kind, name, and member names.
+func parseGraphQL(t *testing.T, src string) (string, string, []string) {
+	t.Helper()
+	lines := strings.Split(strings.TrimSuffix(src, "\n"), "\n")
+	if len(lines) < 2 || lines[len(lines)-1] != "}" {
+		t.Fatalf("malformed definition %q", src)
+	}
+	m := graphQLOpenRE.FindStringSubmatch(lines[0])
+	if m == nil {
+		t.Fatalf("malformed definition header %q", lines[0])
+	}
+	kind, name := m[1], m[2]
+	memberRE := graphQLFieldRE
+	if kind == "enum" {
+		memberRE = graphQLValueRE
+	}
+	var members []string
+	for _, line := range lines[1 : len(lines)-1] {
+		m := memberRE.FindStringSubmatch(line)
+		if m == nil {
+			t.Fatalf("malformed %s member %q", kind, line)
+		}
+		members = append(members, m[1])
+	}
+	return kind, name, members
+}
+
+func TestEnumType_WriteGraphQLEnum(t *testing.T) {
+	var buf bytes.Buffer
+	color := MakeEnumType("Color", testColorData)
+	if err := color.WriteGraphQLEnum(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	kind, name, members := parseGraphQL(t, buf.String())
+	expected := []string{"COLOR_RED", "COLOR_GREEN", "COLOR_BLUE", "COLOR_PURPLE"}
+	if kind != "enum" || name != "Color" || strings.Join(members, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected enum Color %v, got %s %s %v", expected, kind, name, members)
+	}
+}
+
+func TestBitfieldType_WriteGraphQLEnum(t *testing.T) {
+	var buf bytes.Buffer
+	perm := MakeBitfieldType("Perm", testPermData)
+	if err := perm.WriteGraphQLEnum(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	kind, name, members := parseGraphQL(t, buf.String())
+	expected := []string{"exec", "write", "read"}
+	if kind != "type" || name != "Perm" || strings.Join(members, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected type Perm %v, got %s %s %v", expected, kind, name, members)
+	}
+}
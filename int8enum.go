@@ -0,0 +1,198 @@
+package enumhelper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Int8EnumData holds data about one particular int8-valued enum value.
+type Int8EnumData struct {
+	EnumData
+
+	// Value is the numeric value of this enum value.
+	Value int8
+}
+
+// Int8EnumType holds data about an enum type whose values are stored as int8.
+// Unlike the []EnumData helpers, the values need not be contiguous and may be
+// negative.
+type Int8EnumType struct {
+	// Type gives the Go name for this enum type.
+	Type string
+
+	// Data lists the data for all known values, in definition order.
+	Data []*Int8EnumData
+
+	// Names holds the canonical names for all known values.
+	Names []string
+
+	// ByName maps valid names to the data for the corresponding value.
+	ByName map[string]*Int8EnumData
+
+	// ByValue maps known numeric values to their data.
+	ByValue map[int8]*Int8EnumData
+}
+
+// MakeInt8EnumType initializes and returns an Int8EnumType.
+//
+// As with MakeEnumType, if two values share a name, the name refers to the
+// value that appears first.  Likewise, if two entries share a numeric value,
+// ByValue refers to the entry that appears first.
+func MakeInt8EnumType(typeName string, in []Int8EnumData) Int8EnumType {
+	length := uint(len(in))
+
+	out := Int8EnumType{
+		Type:    typeName,
+		Data:    make([]*Int8EnumData, length),
+		Names:   make([]string, length),
+		ByName:  make(map[string]*Int8EnumData, 4*length),
+		ByValue: make(map[int8]*Int8EnumData, length),
+	}
+
+	addName := func(name string, ptr *Int8EnumData) {
+		if _, found := out.ByName[name]; !found {
+			out.ByName[name] = ptr
+		}
+	}
+
+	for index := uint(0); index < length; index++ {
+		ptr := new(Int8EnumData)
+		*ptr = in[index]
+
+		out.Data[index] = ptr
+		out.Names[index] = ptr.Name
+		if _, found := out.ByValue[ptr.Value]; !found {
+			out.ByValue[ptr.Value] = ptr
+		}
+
+		if ptr.Name != "" {
+			addName(ptr.Name, ptr)
+			addName(strings.ToLower(ptr.Name), ptr)
+		}
+
+		if ptr.GoName != "" {
+			addName(ptr.GoName, ptr)
+			addName(strings.ToLower(ptr.GoName), ptr)
+		}
+
+		for _, alias := range ptr.Aliases {
+			addName(alias, ptr)
+			addName(strings.ToLower(alias), ptr)
+		}
+	}
+	return out
+}
+
+// ToGoString generates a Go string representation for the given enum value.
+func (enum Int8EnumType) ToGoString(value int8) string {
+	if data, found := enum.ByValue[value]; found && data.GoName != "" {
+		return data.GoName
+	}
+	return enum.Type + "(" + strconv.FormatInt(int64(value), 10) + ")"
+}
+
+// ToString generates a string representation for the given enum value.
+func (enum Int8EnumType) ToString(value int8) string {
+	if data, found := enum.ByValue[value]; found && data.Name != "" {
+		return data.Name
+	}
+	return strconv.FormatInt(int64(value), 10)
+}
+
+// ToJSON marshals this enum value to JSON.  Known values without a name are
+// marshaled as JSON numbers.  Returns UnknownEnumValueError if the enum value
+// is not known.
+func (enum Int8EnumType) ToJSON(value int8) ([]byte, error) {
+	data, found := enum.ByValue[value]
+	if !found {
+		return nil, UnknownEnumValueError{
+			Type:  enum.Type,
+			Value: int64(value),
+		}
+	}
+	if data.Name == "" {
+		return json.Marshal(value)
+	}
+	if data.JSON == nil {
+		return json.Marshal(data.Name)
+	}
+	return data.JSON, nil
+}
+
+// FromString parses the string representation of an enum value.  Returns
+// InvalidEnumNameError if the string cannot be parsed, or
+// UnknownEnumValueError if it holds a number which is not a known value.
+func (enum Int8EnumType) FromString(str string) (int8, error) {
+	strPrefix := enum.Type + "("
+	strSuffix := ")"
+	if strings.HasPrefix(str, strPrefix) && strings.HasSuffix(str, strSuffix) {
+		i := uint(len(strPrefix))
+		j := uint(len(str)) - uint(len(strSuffix))
+		str = str[i:j]
+	}
+
+	if data, found := enum.ByName[str]; found {
+		return data.Value, nil
+	}
+
+	if data, found := enum.ByName[strings.ToLower(str)]; found {
+		return data.Value, nil
+	}
+
+	if i64, err := strconv.ParseInt(str, 0, 8); err == nil {
+		return enum.checkValue(int8(i64))
+	}
+
+	return 0, InvalidEnumNameError{
+		Type:    enum.Type,
+		Name:    str,
+		Allowed: enum.Names,
+	}
+}
+
+// FromJSON unmarshals an enum value from JSON.  Returns IsNullError,
+// InvalidEnumNameError, or UnknownEnumValueError if a JSON value was parsed
+// but could not be unmarshaled as an enum value.
+func (enum Int8EnumType) FromJSON(raw []byte) (int8, error) {
+	if raw == nil {
+		panic(errors.New("[]byte is nil"))
+	}
+
+	if bytes.Equal(raw, nullBytes) {
+		return 0, IsNullError{}
+	}
+
+	for _, data := range enum.Data {
+		if data.JSON != nil && bytes.Equal(raw, data.JSON) {
+			return data.Value, nil
+		}
+	}
+
+	var str string
+	err0 := json.Unmarshal(raw, &str)
+	if err0 == nil {
+		return enum.FromString(str)
+	}
+
+	var i8 int8
+	err1 := json.Unmarshal(raw, &i8)
+	if err1 == nil {
+		return enum.checkValue(i8)
+	}
+
+	return 0, err0
+}
+
+// checkValue returns value, or UnknownEnumValueError if value is not known.
+func (enum Int8EnumType) checkValue(value int8) (int8, error) {
+	if _, found := enum.ByValue[value]; !found {
+		return 0, UnknownEnumValueError{
+			Type:  enum.Type,
+			Value: int64(value),
+		}
+	}
+	return value, nil
+}
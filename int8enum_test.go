@@ -0,0 +1,110 @@
+package enumhelper
+
+import (
+	"errors"
+	"testing"
+)
+
+var testTempData = []Int8EnumData{
+	{EnumData: EnumData{GoName: "TempCold", Name: "cold", Aliases: []string{"chilly"}}, Value: -10},
+	{EnumData: EnumData{GoName: "TempMild", Name: "mild"}, Value: 0},
+	{EnumData: EnumData{GoName: "TempWarm", Name: "warm", Aliases: []string{"chilly"}}, Value: 10},
+}
+
+func TestInt8EnumType(t *testing.T) {
+	temp := MakeInt8EnumType("Temp", testTempData)
+
+	type testCase struct {
+		Name  string
+		Parse func() (int8, error)
+		Value int8
+		Err   error
+	}
+
+	testData := []testCase{
+		{"FromString/name", func() (int8, error) { return temp.FromString("warm") }, 10, nil},
+		{"FromString/shared alias", func() (int8, error) { return temp.FromString("chilly") }, -10, nil},
+		{"FromString/known number", func() (int8, error) { return temp.FromString("-10") }, -10, nil},
+		{"FromString/unknown number", func() (int8, error) { return temp.FromString("5") }, 0, ErrUnknownEnumValue},
+		{"FromString/unknown name", func() (int8, error) { return temp.FromString("hot") }, 0, ErrInvalidEnumName},
+		{"FromJSON/known number", func() (int8, error) { return temp.FromJSON([]byte(`10`)) }, 10, nil},
+		{"FromJSON/unknown number", func() (int8, error) { return temp.FromJSON([]byte(`5`)) }, 0, ErrUnknownEnumValue},
+		{"FromJSON/null", func() (int8, error) { return temp.FromJSON([]byte(`null`)) }, 0, ErrNullJSON},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			value, err := row.Parse()
+			if !errors.Is(err, row.Err) {
+				t.Fatalf("expected error %v, got %v", row.Err, err)
+			}
+			if value != row.Value {
+				t.Errorf("expected value %d, got %d", row.Value, value)
+			}
+		})
+	}
+
+	if _, err := temp.ToJSON(5); !errors.Is(err, ErrUnknownEnumValue) {
+		t.Errorf("ToJSON(5): expected %v, got %v", ErrUnknownEnumValue, err)
+	}
+}
+
+func TestInt8EnumType_DuplicateValue(t *testing.T) {
+	temp := MakeInt8EnumType("Temp", []Int8EnumData{
+		{EnumData: EnumData{GoName: "TempCold", Name: "cold"}, Value: -10},
+		{EnumData: EnumData{GoName: "TempFreezing", Name: "freezing"}, Value: -10},
+	})
+
+	if data := temp.ByValue[-10]; data.Name != "cold" {
+		t.Errorf("ByValue[-10]: expected the first entry, cold; got %q", data.Name)
+	}
+	if str := temp.ToString(-10); str != "cold" {
+		t.Errorf("ToString(-10): expected %q, got %q", "cold", str)
+	}
+	if value, err := temp.FromString("freezing"); err != nil || value != -10 {
+		t.Errorf("FromString(freezing): expected -10, nil; got %d, %v", value, err)
+	}
+}
+
+func TestInt8EnumType_RoundTrip(t *testing.T) {
+	temp := MakeInt8EnumType("Temp", []Int8EnumData{
+		{EnumData: EnumData{GoName: "TempFrigid", Name: "frigid"}, Value: -128},
+		{EnumData: EnumData{GoName: "TempCold", Name: "cold"}, Value: -10},
+		{EnumData: EnumData{GoName: "TempMild"}, Value: 0},
+		{EnumData: EnumData{GoName: "TempWarm", Name: "warm", JSON: []byte(`"toasty"`)}, Value: 10},
+		{EnumData: EnumData{GoName: "TempScorching", Name: "scorching"}, Value: 127},
+	})
+
+	for _, data := range temp.Data {
+		value := data.Value
+
+		str := temp.ToString(value)
+		if actual, err := temp.FromString(str); err != nil || actual != value {
+			t.Errorf("FromString(ToString(%d) = %q): expected %d, nil; got %d, %v", value, str, value, actual, err)
+		}
+
+		goStr := temp.ToGoString(value)
+		if actual, err := temp.FromString(goStr); err != nil || actual != value {
+			t.Errorf("FromString(ToGoString(%d) = %q): expected %d, nil; got %d, %v", value, goStr, value, actual, err)
+		}
+
+		raw, err := temp.ToJSON(value)
+		if err != nil {
+			t.Errorf("ToJSON(%d): unexpected error: %v", value, err)
+			continue
+		}
+		if actual, err := temp.FromJSON(raw); err != nil || actual != value {
+			t.Errorf("FromJSON(ToJSON(%d) = %s): expected %d, nil; got %d, %v", value, raw, value, actual, err)
+		}
+	}
+
+	if value, err := temp.FromString("Temp(-10)"); err != nil || value != -10 {
+		t.Errorf("FromString(Temp(-10)): expected -10, nil; got %d, %v", value, err)
+	}
+	if _, err := temp.FromString("Temp(-11)"); !errors.Is(err, ErrUnknownEnumValue) {
+		t.Errorf("FromString(Temp(-11)): expected %v, got %v", ErrUnknownEnumValue, err)
+	}
+	if raw, err := temp.ToJSON(0); err != nil || string(raw) != "0" {
+		t.Errorf("ToJSON(0): expected 0, nil; got %s, %v", raw, err)
+	}
+}
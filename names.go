@@ -0,0 +1,90 @@
+package enumhelper
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// snakeCase converts an identifier such as "ReadWrite", "HTTPServer", or
+// "read-write" into lower_snake_case.
+func snakeCase(str string) string {
+	runes := []rune(str)
+	var buf strings.Builder
+	buf.Grow(len(str) + 4)
+	pendingUnderscore := false
+	for i, ch := range runes {
+		if !unicode.IsLetter(ch) && !unicode.IsDigit(ch) {
+			pendingUnderscore = buf.Len() != 0
+			continue
+		}
+		if unicode.IsUpper(ch) && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := (i+1 < len(runes)) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				pendingUnderscore = buf.Len() != 0
+			}
+		}
+		if pendingUnderscore {
+			buf.WriteByte('_')
+			pendingUnderscore = false
+		}
+		buf.WriteRune(unicode.ToLower(ch))
+	}
+	return buf.String()
+}
+
+// screamingSnakeCase converts an identifier into UPPER_SNAKE_CASE.
+func screamingSnakeCase(str string) string {
+	return strings.ToUpper(snakeCase(str))
+}
+
+// jsonQuote returns str as a JSON string literal.  JSON string literals are
+// also valid string literals in YAML, JavaScript, and most other languages.
+func jsonQuote(str string) string {
+	// json.Marshal cannot fail for a string.
+	raw, _ := json.Marshal(str)
+	return string(raw)
+}
+
+// checkIdentifier verifies that ident, which was derived from name, is a valid
+// identifier for a generated schema and is not already in seen, then adds it to
+// seen.
+func checkIdentifier(typeName, name, ident string, seen map[string]struct{}) error {
+	if !isIdentifier(ident) {
+		return InvalidIdentifierError{
+			Type:       typeName,
+			Name:       name,
+			Identifier: ident,
+		}
+	}
+	if _, found := seen[ident]; found {
+		return DuplicateNameError{
+			Type: typeName,
+			Name: ident,
+		}
+	}
+	seen[ident] = struct{}{}
+	return nil
+}
+
+// isIdentifier returns true iff str is a valid identifier in proto3, GraphQL,
+// and Avro: an ASCII letter or underscore, followed by zero or more ASCII
+// letters, digits, or underscores.
+func isIdentifier(str string) bool {
+	if str == "" {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		switch {
+		case ch == '_':
+		case ch >= 'a' && ch <= 'z':
+		case ch >= 'A' && ch <= 'Z':
+		case ch >= '0' && ch <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
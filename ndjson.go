@@ -0,0 +1,112 @@
+package enumhelper
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MaxNDJSONLineSize is the limit on the length of a single line read by
+// EnumType.UnmarshalNDJSON and BitfieldType.UnmarshalNDJSON.
+const MaxNDJSONLineSize = bufio.MaxScanTokenSize
+
+// forEachNDJSONLine calls fn with each non-blank line read from r.  Errors
+// returned by fn are annotated with the line number.  Returns
+// InputTooLargeError, also annotated with the line number, if a line is
+// longer than MaxNDJSONLineSize.
+func forEachNDJSONLine(r io.Reader, typeName string, fn func(line []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, MaxNDJSONLineSize)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return fmt.Errorf("line %d: %w", lineNum, err)
+		}
+	}
+	err := scanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		return fmt.Errorf("line %d: %w", lineNum+1, InputTooLargeError{
+			Type:  typeName,
+			Limit: MaxNDJSONLineSize,
+		})
+	}
+	return err
+}
+
+// MarshalNDJSON writes the given enum values to w as newline-delimited JSON,
+// one value per line, in the format produced by ToJSON.  Returns
+// InvalidEnumValueError if any enum value is out of range.
+func (enum EnumType) MarshalNDJSON(values []uint, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, value := range values {
+		raw, err := enum.ToJSON(value)
+		if err != nil {
+			return err
+		}
+		bw.Write(raw)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// UnmarshalNDJSON reads newline-delimited JSON from r, as written by
+// MarshalNDJSON, and parses each line with FromJSON.  Blank lines are
+// skipped.  Errors are annotated with the line number.  Returns
+// InputTooLargeError if a line is longer than MaxNDJSONLineSize.
+func (enum EnumType) UnmarshalNDJSON(r io.Reader) ([]uint, error) {
+	var out []uint
+	err := forEachNDJSONLine(r, enum.Type, func(line []byte) error {
+		value, err := enum.FromJSON(line)
+		if err != nil {
+			return err
+		}
+		out = append(out, value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarshalNDJSON writes the given bitfield values to w as newline-delimited
+// JSON, one value per line, in the format produced by ToJSON.
+func (bitfield BitfieldType) MarshalNDJSON(values []uint64, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, value := range values {
+		raw, err := bitfield.ToJSON(value)
+		if err != nil {
+			return err
+		}
+		bw.Write(raw)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// UnmarshalNDJSON reads newline-delimited JSON from r, as written by
+// MarshalNDJSON, and parses each line with FromJSON.  Blank lines are
+// skipped.  Errors are annotated with the line number.  Returns
+// InputTooLargeError if a line is longer than MaxNDJSONLineSize.
+func (bitfield BitfieldType) UnmarshalNDJSON(r io.Reader) ([]uint64, error) {
+	var out []uint64
+	err := forEachNDJSONLine(r, bitfield.Type, func(line []byte) error {
+		u64, err := bitfield.FromJSON(line)
+		if err != nil {
+			return err
+		}
+		out = append(out, u64)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
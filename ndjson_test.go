@@ -0,0 +1,94 @@
+package enumhelper
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEnumType_NDJSON(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	values := []uint{0, 1, 2, 3, 3, 2, 1, 0, 2, 2}
+	var buf bytes.Buffer
+	if err := color.MarshalNDJSON(values, &buf); err != nil {
+		t.Fatalf("MarshalNDJSON: unexpected error: %v", err)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != len(values) {
+		t.Errorf("MarshalNDJSON: expected %d lines, got %d", len(values), n)
+	}
+
+	actual, err := color.UnmarshalNDJSON(&buf)
+	if err != nil {
+		t.Fatalf("UnmarshalNDJSON: unexpected error: %v", err)
+	}
+	if len(actual) != len(values) {
+		t.Fatalf("UnmarshalNDJSON: expected %v, got %v", values, actual)
+	}
+	for i := range values {
+		if actual[i] != values[i] {
+			t.Errorf("UnmarshalNDJSON: expected %v, got %v", values, actual)
+			break
+		}
+	}
+
+	if _, err := color.UnmarshalNDJSON(strings.NewReader("\"red\"\n\"pink\"\n")); !errors.Is(err, ErrInvalidEnumName) || !strings.HasPrefix(err.Error(), "line 2: ") {
+		t.Errorf("UnmarshalNDJSON(pink): expected line 2: %v, got %v", ErrInvalidEnumName, err)
+	}
+}
+
+func TestEnumType_UnmarshalNDJSON_LongLine(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	input := "\"red\"\n\"" + strings.Repeat("x", MaxNDJSONLineSize) + "\"\n"
+	_, err := color.UnmarshalNDJSON(strings.NewReader(input))
+	var x InputTooLargeError
+	if !errors.As(err, &x) || x.Limit != MaxNDJSONLineSize || !strings.HasPrefix(err.Error(), "line 2: ") {
+		t.Errorf("expected line 2: InputTooLargeError with Limit %d, got %#v", MaxNDJSONLineSize, err)
+	}
+}
+
+func TestBitfieldType_NDJSON(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	values := []uint64{0x0, 0x1, 0x2, 0x4, 0x3, 0x5, 0x7, 0x0, 0x6}
+	for _, format := range []string{"pipe", "array", "integer"} {
+		t.Run(format, func(t *testing.T) {
+			perm := perm.WithEncodedAs(format)
+
+			var buf bytes.Buffer
+			if err := perm.MarshalNDJSON(values, &buf); err != nil {
+				t.Fatalf("MarshalNDJSON: unexpected error: %v", err)
+			}
+			if n := strings.Count(buf.String(), "\n"); n != len(values) {
+				t.Errorf("MarshalNDJSON: expected %d lines, got %d", len(values), n)
+			}
+
+			actual, err := perm.UnmarshalNDJSON(&buf)
+			if err != nil {
+				t.Fatalf("UnmarshalNDJSON: unexpected error: %v", err)
+			}
+			if len(actual) != len(values) {
+				t.Fatalf("UnmarshalNDJSON: expected %v, got %v", values, actual)
+			}
+			for i := range values {
+				if actual[i] != values[i] {
+					t.Errorf("UnmarshalNDJSON: expected %v, got %v", values, actual)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestBitfieldType_UnmarshalNDJSON_LongLine(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	input := "\"read\"\n\n\"" + strings.Repeat("r|", MaxNDJSONLineSize/2) + "r\"\n"
+	_, err := perm.UnmarshalNDJSON(strings.NewReader(input))
+	var x InputTooLargeError
+	if !errors.As(err, &x) || x.Type != "Perm" || !strings.HasPrefix(err.Error(), "line 3: ") {
+		t.Errorf("expected line 3: InputTooLargeError for Perm, got %#v", err)
+	}
+}
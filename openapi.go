@@ -0,0 +1,65 @@
+package enumhelper
+
+import (
+	"bytes"
+	"io"
+)
+
+// WriteOpenAPIComponent writes a YAML fragment describing this enum type,
+// suitable for inclusion in the "components/schemas" section of an OpenAPI 3
+// document.
+//
+// The schema is a string restricted to the canonical names of the enum
+// values.
+func (enum EnumType) WriteOpenAPIComponent(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString(enum.Type)
+	buf.WriteString(":\n")
+	buf.WriteString("  type: string\n")
+	first := true
+	enum.ForEach(func(data AnnotatedEnumData) {
+		name := data.canonicalName()
+		if name == "" {
+			return
+		}
+		if first {
+			buf.WriteString("  enum:\n")
+			first = false
+		}
+		buf.WriteString("    - ")
+		buf.WriteString(jsonQuote(name))
+		buf.WriteString("\n")
+	})
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteOpenAPIComponent writes a YAML fragment describing this bitfield type,
+// suitable for inclusion in the "components/schemas" section of an OpenAPI 3
+// document.
+//
+// The schema accepts either a pipe-delimited string (the format produced by
+// ToJSON) or an array of bit names.
+func (bitfield BitfieldType) WriteOpenAPIComponent(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString(bitfield.Type)
+	buf.WriteString(":\n")
+	buf.WriteString("  oneOf:\n")
+	buf.WriteString("    - type: string\n")
+	buf.WriteString("      description: Pipe-delimited list of bit names.\n")
+	buf.WriteString("    - type: array\n")
+	buf.WriteString("      items:\n")
+	buf.WriteString("        type: string\n")
+	if len(bitfield.Names) != 0 {
+		buf.WriteString("        enum:\n")
+		for _, name := range bitfield.Names {
+			buf.WriteString("          - ")
+			buf.WriteString(jsonQuote(name))
+			buf.WriteString("\n")
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,71 @@
+package enumhelper
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestEnumType_WriteOpenAPIComponent(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	var buf bytes.Buffer
+	if err := color.WriteOpenAPIComponent(&buf); err != nil {
+		t.Fatalf("WriteOpenAPIComponent: unexpected error: %v", err)
+	}
+
+	var doc map[string]struct {
+		Type string   `yaml:"type"`
+		Enum []string `yaml:"enum"`
+	}
+	if err := yaml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("yaml.Unmarshal: unexpected error: %v\n%s", err, buf.String())
+	}
+
+	schema, found := doc["Color"]
+	if !found {
+		t.Fatalf("expected a Color schema, got %v", doc)
+	}
+	if schema.Type != "string" {
+		t.Errorf("expected type string, got %q", schema.Type)
+	}
+	if got, expect := strings.Join(schema.Enum, ","), "red,green,blue,purple"; got != expect {
+		t.Errorf("expected enum %q, got %q", expect, got)
+	}
+}
+
+func TestBitfieldType_WriteOpenAPIComponent(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	var buf bytes.Buffer
+	if err := perm.WriteOpenAPIComponent(&buf); err != nil {
+		t.Fatalf("WriteOpenAPIComponent: unexpected error: %v", err)
+	}
+
+	type schema struct {
+		Type  string   `yaml:"type"`
+		Items *schema  `yaml:"items"`
+		Enum  []string `yaml:"enum"`
+	}
+	var doc map[string]struct {
+		OneOf []schema `yaml:"oneOf"`
+	}
+	if err := yaml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("yaml.Unmarshal: unexpected error: %v\n%s", err, buf.String())
+	}
+
+	oneOf := doc["Perm"].OneOf
+	if len(oneOf) != 2 || oneOf[0].Type != "string" || oneOf[1].Type != "array" || oneOf[1].Items == nil {
+		t.Fatalf("expected oneOf string or array, got %+v", oneOf)
+	}
+	if got, expect := strings.Join(oneOf[1].Items.Enum, ","), strings.Join(perm.Names, ","); got != expect {
+		t.Errorf("expected items enum %q, got %q", expect, got)
+	}
+	for _, name := range []string{"exec", "write", "read"} {
+		if !strings.Contains(strings.Join(oneOf[1].Items.Enum, ","), name) {
+			t.Errorf("expected items enum to contain %q, got %v", name, oneOf[1].Items.Enum)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package enumhelper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// ProtoMessageDescriptor describes a protobuf message.  It mirrors the subset
+// of google.protobuf.DescriptorProto that is needed to describe a bitfield.
+type ProtoMessageDescriptor struct {
+	// Name is the name of the message type.
+	Name string
+
+	// Field lists the fields of the message.
+	Field []ProtoFieldDescriptor
+}
+
+// ProtoFieldDescriptor describes one field of a protobuf message.  It mirrors
+// the subset of google.protobuf.FieldDescriptorProto that is needed to
+// describe a bitfield bit.
+type ProtoFieldDescriptor struct {
+	// Name is the name of the field, in lower_snake_case.
+	Name string
+
+	// Number is the field number.
+	Number int32
+
+	// Type is the proto3 scalar type of the field, e.g. "bool".
+	Type string
+}
+
+// ToProtoDescriptor returns a description of this bitfield type as a protobuf
+// message.
+//
+// Bitfields have no direct proto3 equivalent, so the bitfield is described as
+// a message with one bool field per named bit.  Field numbers are the bit
+// index plus one, and field names are the bits' canonical names in
+// lower_snake_case.  Returns InvalidIdentifierError if a bit's name does not
+// convert to a valid proto identifier, or DuplicateNameError if two bits'
+// names convert to the same field name.
+func (bitfield BitfieldType) ToProtoDescriptor() (ProtoMessageDescriptor, error) {
+	out := ProtoMessageDescriptor{
+		Name:  bitfield.Type,
+		Field: make([]ProtoFieldDescriptor, 0, len(bitfield.Names)),
+	}
+	seen := make(map[string]struct{}, len(bitfield.Names))
+	var err error
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		name := data.canonicalName()
+		if name == "" || err != nil {
+			return
+		}
+		fieldName := snakeCase(name)
+		if err = checkIdentifier(bitfield.Type, name, fieldName, seen); err != nil {
+			return
+		}
+		out.Field = append(out.Field, ProtoFieldDescriptor{
+			Name:   fieldName,
+			Number: int32(data.Index) + 1,
+			Type:   "bool",
+		})
+	})
+	if err != nil {
+		return ProtoMessageDescriptor{}, err
+	}
+	return out, nil
+}
+
+// WriteProto writes a proto3 schema describing this bitfield type, using the
+// message described by ToProtoDescriptor.  If packageName is empty, no
+// package statement is written.  Returns any error from ToProtoDescriptor
+// without writing anything.
+func (bitfield BitfieldType) WriteProto(w io.Writer, packageName string) error {
+	desc, err := bitfield.ToProtoDescriptor()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	writeProtoHeader(&buf, packageName)
+	buf.WriteString("message ")
+	buf.WriteString(desc.Name)
+	buf.WriteString(" {\n")
+	for _, field := range desc.Field {
+		buf.WriteString("  ")
+		buf.WriteString(field.Type)
+		buf.WriteString(" ")
+		buf.WriteString(field.Name)
+		buf.WriteString(" = ")
+		buf.WriteString(strconv.FormatInt(int64(field.Number), 10))
+		buf.WriteString(";\n")
+	}
+	buf.WriteString("}\n")
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// WriteProto writes a proto3 enum definition for this enum type.  Each enum
+// value is named by protoEnumValueName and numbered by its value.  If
+// packageName is empty, no package statement is written.  Returns
+// InvalidIdentifierError if a value's name does not convert to a valid proto
+// identifier, or DuplicateNameError if two values' names convert to the same
+// identifier; nothing is written in either case.
+func (enum EnumType) WriteProto(w io.Writer, packageName string) error {
+	seen := make(map[string]struct{}, len(enum.Data))
+	for _, ptr := range enum.Data {
+		if err := checkIdentifier(enum.Type, ptr.canonicalName(), protoEnumValueName(*ptr), seen); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	writeProtoHeader(&buf, packageName)
+	buf.WriteString("enum ")
+	buf.WriteString(enum.Type)
+	buf.WriteString(" {\n")
+	for _, ptr := range enum.Data {
+		buf.WriteString("  ")
+		buf.WriteString(protoEnumValueName(*ptr))
+		buf.WriteString(" = ")
+		buf.WriteString(strconv.FormatUint(uint64(ptr.Value), 10))
+		buf.WriteString(";\n")
+	}
+	buf.WriteString("}\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeProtoHeader(buf *bytes.Buffer, packageName string) {
+	buf.WriteString("syntax = \"proto3\";\n\n")
+	if packageName != "" {
+		buf.WriteString("package ")
+		buf.WriteString(packageName)
+		buf.WriteString(";\n\n")
+	}
+}
+
+// protoEnumValueName returns the proto-style name for an enum value: its
+// GoName (or Name, if GoName is empty) in UPPER_SNAKE_CASE.
+func protoEnumValueName(data AnnotatedEnumData) string {
+	name := data.GoName
+	if name == "" {
+		name = data.Name
+	}
+	return screamingSnakeCase(name)
+}
+
+// MarshalProtoJSON marshals this enum value to JSON following the protobuf
+// JSON mapping.  Known values are marshaled as their GoName in
+// UPPER_SNAKE_CASE; unknown values are marshaled as their decimal integer,
+// also as a JSON string.
+func (enum EnumType) MarshalProtoJSON(value uint) ([]byte, error) {
+	if enum.Contains(value) {
+		if name := protoEnumValueName(*enum.Data[value]); name != "" {
+			return json.Marshal(name)
+		}
+	}
+	return json.Marshal(strconv.FormatUint(uint64(value), 10))
+}
+
+// UnmarshalProtoJSON unmarshals an enum value from JSON following the
+// protobuf JSON mapping.  It accepts the names produced by MarshalProtoJSON,
+// any name accepted by FromString, and integers either as JSON numbers or as
+// JSON strings.  Integers are accepted even if they are not known values.
+// Returns IsNullError or InvalidEnumNameError if a JSON value was parsed but
+// could not be unmarshaled as an enum value, or the error returned by the
+// WithValidationHook function.
+func (enum EnumType) UnmarshalProtoJSON(raw []byte) (uint, error) {
+	if raw == nil {
+		panic(errors.New("[]byte is nil"))
+	}
+
+	if bytes.Equal(raw, nullBytes) {
+		return 0, IsNullError{}
+	}
+
+	var str string
+	err0 := json.Unmarshal(raw, &str)
+	if err0 == nil {
+		for _, ptr := range enum.Data {
+			if str == protoEnumValueName(*ptr) {
+				return enum.validate(ptr.Value)
+			}
+		}
+		if u64, err := strconv.ParseUint(str, 10, 0); err == nil {
+			return enum.validate(uint(u64))
+		}
+		return enum.FromString(str)
+	}
+
+	var num uint
+	err1 := json.Unmarshal(raw, &num)
+	if err1 == nil {
+		return enum.validate(num)
+	}
+
+	return 0, err0
+}
@@ -0,0 +1,222 @@
+package enumhelper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// protoFile is the result of parsing the proto3 subset written by WriteProto.
+type protoFile struct {
+	Package string
+	Kind    string
+	Name    string
+	Entries []protoEntry
+}
+
+type protoEntry struct {
+	Type   string
+	Name   string
+	Number int
+}
+
+var (
+	protoSyntaxRE  = regexp.MustCompile(`^syntax = "proto3";$`)
+	protoPackageRE = regexp.MustCompile(`^package ([A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)*);$`)
+	protoOpenRE    = regexp.MustCompile(`^(message|enum) ([A-Za-z_][A-Za-z0-9_]*) \{$`)
+	protoFieldRE   = regexp.MustCompile(`^  (?:([a-z0-9]+) )?([A-Za-z_][A-Za-z0-9_]*) = ([0-9]+);$`)
+)
+
+// parseProto parses a single-definition proto3 file, rejecting anything
+// that is not well-formed.  Message fields must have a type and unique
+// positive numbers; enum values must have no type and start at zero.
+func parseProto(src string) (protoFile, error) {
+	var out protoFile
+	if !strings.HasSuffix(src, "}\n") {
+		return out, errors.New("missing closing brace")
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(src, "}\n"), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 || !protoSyntaxRE.MatchString(lines[0]) {
+		return out, errors.New("missing syntax statement")
+	}
+	lines = lines[1:]
+	if len(lines) != 0 {
+		if m := protoPackageRE.FindStringSubmatch(lines[0]); m != nil {
+			out.Package = m[1]
+			lines = lines[1:]
+		}
+	}
+	if len(lines) == 0 {
+		return out, errors.New("missing definition")
+	}
+	m := protoOpenRE.FindStringSubmatch(lines[0])
+	if m == nil {
+		return out, fmt.Errorf("malformed definition %q", lines[0])
+	}
+	out.Kind, out.Name = m[1], m[2]
+
+	names := make(map[string]struct{})
+	numbers := make(map[int]struct{})
+	for _, line := range lines[1:] {
+		m := protoFieldRE.FindStringSubmatch(line)
+		if m == nil {
+			return out, fmt.Errorf("malformed entry %q", line)
+		}
+		number, _ := strconv.Atoi(m[3])
+		entry := protoEntry{Type: m[1], Name: m[2], Number: number}
+		if (out.Kind == "message") != (entry.Type != "") {
+			return out, fmt.Errorf("entry %q has the wrong form for a %s", line, out.Kind)
+		}
+		if out.Kind == "message" && number == 0 {
+			return out, fmt.Errorf("field %q has number 0", entry.Name)
+		}
+		if out.Kind == "enum" && len(out.Entries) == 0 && number != 0 {
+			return out, fmt.Errorf("first enum value %q is not 0", entry.Name)
+		}
+		if _, found := names[entry.Name]; found {
+			return out, fmt.Errorf("duplicate name %q", entry.Name)
+		}
+		if _, found := numbers[number]; found {
+			return out, fmt.Errorf("duplicate number %d", number)
+		}
+		names[entry.Name] = struct{}{}
+		numbers[number] = struct{}{}
+		out.Entries = append(out.Entries, entry)
+	}
+	return out, nil
+}
+
+func TestBitfieldType_ToProtoDescriptor(t *testing.T) {
+	perm := MakeBitfieldType("Perm", []BitfieldData{
+		{GoName: "PermExec", Name: "exec"},
+		{},
+		{GoName: "PermReadWrite", Name: "ReadWrite"},
+	})
+
+	desc, err := perm.ToProtoDescriptor()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []ProtoFieldDescriptor{
+		{Name: "exec", Number: 1, Type: "bool"},
+		{Name: "read_write", Number: 3, Type: "bool"},
+	}
+	if desc.Name != "Perm" || fmt.Sprint(desc.Field) != fmt.Sprint(expected) {
+		t.Errorf("expected Perm %v, got %s %v", expected, desc.Name, desc.Field)
+	}
+}
+
+func TestBitfieldType_ToProtoDescriptor_Invalid(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Data     []BitfieldData
+		Expected error
+	}
+
+	testData := [...]testCase{
+		{
+			Name: "collision",
+			Data: []BitfieldData{
+				{GoName: "PermReadWrite", Name: "read-write"},
+				{GoName: "PermReadWrite2", Name: "ReadWrite"},
+			},
+			Expected: DuplicateNameError{Type: "Perm", Name: "read_write"},
+		},
+		{
+			Name:     "empty",
+			Data:     []BitfieldData{{GoName: "PermDash", Name: "--"}},
+			Expected: InvalidIdentifierError{Type: "Perm", Name: "--", Identifier: ""},
+		},
+		{
+			Name:     "leading-digit",
+			Data:     []BitfieldData{{GoName: "PermNine", Name: "9lives"}},
+			Expected: InvalidIdentifierError{Type: "Perm", Name: "9lives", Identifier: "9lives"},
+		},
+		{
+			Name:     "non-ascii",
+			Data:     []BitfieldData{{GoName: "PermE", Name: "été"}},
+			Expected: InvalidIdentifierError{Type: "Perm", Name: "été", Identifier: "été"},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			perm := MakeBitfieldType("Perm", row.Data)
+			if _, err := perm.ToProtoDescriptor(); err != row.Expected {
+				t.Errorf("ToProtoDescriptor: expected %#v, got %#v", row.Expected, err)
+			}
+			var buf bytes.Buffer
+			if err := perm.WriteProto(&buf, ""); err != row.Expected {
+				t.Errorf("WriteProto: expected %#v, got %#v", row.Expected, err)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("WriteProto: expected no output, got %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestBitfieldType_WriteProto(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	var buf bytes.Buffer
+	if err := perm.WriteProto(&buf, "example.v1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	file, err := parseProto(buf.String())
+	if err != nil {
+		t.Fatalf("failed to parse %q: %v", buf.String(), err)
+	}
+	expected := []protoEntry{
+		{Type: "bool", Name: "exec", Number: 1},
+		{Type: "bool", Name: "write", Number: 2},
+		{Type: "bool", Name: "read", Number: 3},
+	}
+	if file.Package != "example.v1" || file.Kind != "message" || file.Name != "Perm" || fmt.Sprint(file.Entries) != fmt.Sprint(expected) {
+		t.Errorf("expected package example.v1, message Perm %v; got %+v", expected, file)
+	}
+}
+
+func TestEnumType_WriteProto(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	var buf bytes.Buffer
+	if err := color.WriteProto(&buf, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	file, err := parseProto(buf.String())
+	if err != nil {
+		t.Fatalf("failed to parse %q: %v", buf.String(), err)
+	}
+	expected := []protoEntry{
+		{Name: "COLOR_RED", Number: 0},
+		{Name: "COLOR_GREEN", Number: 1},
+		{Name: "COLOR_BLUE", Number: 2},
+		{Name: "COLOR_PURPLE", Number: 3},
+	}
+	if file.Package != "" || file.Kind != "enum" || file.Name != "Color" || fmt.Sprint(file.Entries) != fmt.Sprint(expected) {
+		t.Errorf("expected enum Color %v; got %+v", expected, file)
+	}
+
+	color = MakeEnumType("Color", []EnumData{
+		{GoName: "ColorRed", Name: "red"},
+		{GoName: "Color_Red", Name: "rojo"},
+	})
+	buf.Reset()
+	expectedErr := DuplicateNameError{Type: "Color", Name: "COLOR_RED"}
+	if err := color.WriteProto(&buf, ""); err != expectedErr {
+		t.Errorf("WriteProto(collision): expected %#v, got %#v", expectedErr, err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("WriteProto(collision): expected no output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,31 @@
+package enumhelper
+
+import (
+	"io"
+	"strings"
+)
+
+// DefaultMaxReadSize is the default limit on the number of bytes read by
+// EnumType.ParseFromReader and BitfieldType.ParseFromReader.
+const DefaultMaxReadSize = 4096
+
+// readAllLimited reads all bytes from r and returns them as a string with
+// surrounding whitespace trimmed.  Returns InputTooLargeError if r holds more
+// than limit bytes.
+func readAllLimited(r io.Reader, typeName string, limit int64) (string, error) {
+	if limit <= 0 {
+		limit = DefaultMaxReadSize
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(raw)) > limit {
+		return "", InputTooLargeError{
+			Type:  typeName,
+			Limit: limit,
+		}
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
@@ -0,0 +1,66 @@
+package enumhelper
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEnumType_ParseFromReader(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	value, err := color.ParseFromReader(strings.NewReader("  blue\n"))
+	if err != nil {
+		t.Fatalf("ParseFromReader: unexpected error: %v", err)
+	}
+	if value != 2 {
+		t.Errorf("ParseFromReader: expected 2, got %d", value)
+	}
+
+	_, err = color.ParseFromReader(strings.NewReader("chartreuse"))
+	if !errors.Is(err, ErrInvalidEnumName) {
+		t.Errorf("ParseFromReader: expected InvalidEnumNameError, got %v", err)
+	}
+
+	limited := color.WithMaxReadSize(4)
+	value, err = limited.ParseFromReader(strings.NewReader("blue"))
+	if err != nil || value != 2 {
+		t.Errorf("ParseFromReader: expected 2, nil at the limit; got %d, %v", value, err)
+	}
+
+	_, err = limited.ParseFromReader(strings.NewReader("purple"))
+	var tooLarge InputTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("ParseFromReader: expected InputTooLargeError, got %v", err)
+	}
+	if tooLarge.Type != "Color" || tooLarge.Limit != 4 {
+		t.Errorf("ParseFromReader: expected {Color 4}, got %+v", tooLarge)
+	}
+}
+
+func TestBitfieldType_ParseFromReader(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	value, err := perm.ParseFromReader(strings.NewReader("\tread|write \n"))
+	if err != nil {
+		t.Fatalf("ParseFromReader: unexpected error: %v", err)
+	}
+	if value != 0x6 {
+		t.Errorf("ParseFromReader: expected 0x6, got 0x%x", value)
+	}
+
+	_, err = perm.ParseFromReader(strings.NewReader("read|delete"))
+	if !errors.Is(err, ErrInvalidBitfieldName) {
+		t.Errorf("ParseFromReader: expected InvalidBitfieldNameError, got %v", err)
+	}
+
+	limited := perm.WithMaxReadSize(4)
+	_, err = limited.ParseFromReader(strings.NewReader("read|write|exec"))
+	var tooLarge InputTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("ParseFromReader: expected InputTooLargeError, got %v", err)
+	}
+	if tooLarge.Type != "Perm" || tooLarge.Limit != 4 {
+		t.Errorf("ParseFromReader: expected {Perm 4}, got %+v", tooLarge)
+	}
+}
@@ -0,0 +1,172 @@
+package enumhelper
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MakeEnumDataFromStruct builds an enum definition from the fields of a
+// struct, which may be passed by value or by pointer.  Each exported field
+// defines one enum value, in field order; fields tagged `enum:"-"` are
+// skipped.  The field name becomes the GoName.
+//
+// The `enum` tag holds a comma-separated list of key=value options:
+//
+//	name=NAME    sets Name (default: the field name)
+//	alias=ALIAS  adds an alias; may be repeated
+//	json=JSON    sets JSON to the given JSON text, which may not contain commas
+//
+// For example:
+//
+//	type colors struct {
+//		ColorRed   struct{} `enum:"name=red,alias=r"`
+//		ColorGreen struct{} `enum:"name=green,alias=g"`
+//	}
+//
+//	var colorData, _ = MakeEnumDataFromStruct(colors{})
+func MakeEnumDataFromStruct(i interface{}) ([]EnumData, error) {
+	t, err := structType(i)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]EnumData, 0, t.NumField())
+	for fieldIndex := 0; fieldIndex < t.NumField(); fieldIndex++ {
+		field := t.Field(fieldIndex)
+		tag, hasTag := field.Tag.Lookup("enum")
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+
+		data := EnumData{GoName: field.Name, Name: field.Name}
+		if hasTag {
+			err := forEachTagOption("enum", field.Name, tag, func(key, value string) bool {
+				switch key {
+				case "name":
+					data.Name = value
+				case "alias":
+					data.Aliases = append(data.Aliases, value)
+				case "json":
+					data.JSON = []byte(value)
+				default:
+					return false
+				}
+				return true
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, data)
+	}
+	return out, nil
+}
+
+// structType returns the struct type of i, which must be a struct or a
+// pointer to a struct.
+func structType(i interface{}) (reflect.Type, error) {
+	t := reflect.TypeOf(i)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected struct or pointer to struct, got %T", i)
+	}
+	return t, nil
+}
+
+// forEachTagOption parses a struct tag of the form "key=value,key=value" and
+// calls fn for each option.  If fn returns false, the key is reported as
+// unknown.
+func forEachTagOption(tagName string, fieldName string, tag string, fn func(key, value string) bool) error {
+	for _, option := range strings.Split(tag, ",") {
+		if option == "" {
+			continue
+		}
+		eq := strings.IndexByte(option, '=')
+		if eq < 0 {
+			return fmt.Errorf("field %s: %s tag: option %q is not of the form key=value", fieldName, tagName, option)
+		}
+		key, value := option[:eq], option[eq+1:]
+		if !fn(key, value) {
+			return fmt.Errorf("field %s: %s tag: unknown option %q", fieldName, tagName, key)
+		}
+	}
+	return nil
+}
+
+// MakeBitfieldDataFromStruct builds a bitfield definition from the fields of
+// a struct, which may be passed by value or by pointer, for use with
+// MakeBitfieldType.  Each exported field defines one bit; fields tagged
+// `bitfield:"-"` are skipped.  The field name becomes the GoName.
+//
+// The `bitfield` tag holds a comma-separated list of key=value options:
+//
+//	name=NAME    sets Name (default: the field name)
+//	alias=ALIAS  adds an alias; may be repeated
+//	index=N      sets the bit index (default: one more than the previous bit)
+//
+// For example:
+//
+//	type perms struct {
+//		PermExec  struct{} `bitfield:"name=exec,index=0"`
+//		PermWrite struct{} `bitfield:"name=write,index=1"`
+//		PermRead  struct{} `bitfield:"name=read,index=2"`
+//	}
+//
+//	var permData, _ = MakeBitfieldDataFromStruct(perms{})
+func MakeBitfieldDataFromStruct(i interface{}) ([]BitfieldData, error) {
+	t, err := structType(i)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []BitfieldData
+	nextIndex := uint64(0)
+	for fieldIndex := 0; fieldIndex < t.NumField(); fieldIndex++ {
+		field := t.Field(fieldIndex)
+		tag, hasTag := field.Tag.Lookup("bitfield")
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+
+		data := BitfieldData{GoName: field.Name, Name: field.Name}
+		index := nextIndex
+		var indexErr error
+		if hasTag {
+			err := forEachTagOption("bitfield", field.Name, tag, func(key, value string) bool {
+				switch key {
+				case "name":
+					data.Name = value
+				case "alias":
+					data.Aliases = append(data.Aliases, value)
+				case "index":
+					index, indexErr = strconv.ParseUint(value, 10, 64)
+				default:
+					return false
+				}
+				return true
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if indexErr != nil {
+			return nil, fmt.Errorf("field %s: bitfield tag: invalid index: %w", field.Name, indexErr)
+		}
+		if index >= 64 {
+			return nil, fmt.Errorf("field %s: bitfield tag: index %d must be < 64", field.Name, index)
+		}
+		for uint64(len(out)) <= index {
+			out = append(out, BitfieldData{})
+		}
+		if out[index].GoName != "" {
+			return nil, fmt.Errorf("field %s: bitfield tag: index %d is already used by field %s", field.Name, index, out[index].GoName)
+		}
+		out[index] = data
+		nextIndex = index + 1
+	}
+	return out, nil
+}
@@ -0,0 +1,156 @@
+package enumhelper
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMakeEnumDataFromStruct(t *testing.T) {
+	type colors struct {
+		ColorRed    struct{} `enum:"name=red,alias=r"`
+		ColorGreen  struct{} `enum:"name=green,alias=g,alias=verde"`
+		ColorBlue   struct{} `enum:"name=blue,json=7"`
+		ColorPurple struct{}
+		ColorSkip   struct{} `enum:"-"`
+		unexported  struct{}
+	}
+
+	expected := []EnumData{
+		{GoName: "ColorRed", Name: "red", Aliases: []string{"r"}},
+		{GoName: "ColorGreen", Name: "green", Aliases: []string{"g", "verde"}},
+		{GoName: "ColorBlue", Name: "blue", JSON: []byte("7")},
+		{GoName: "ColorPurple", Name: "ColorPurple"},
+	}
+
+	for _, input := range []interface{}{colors{}, &colors{}} {
+		actual, err := MakeEnumDataFromStruct(input)
+		if err != nil {
+			t.Errorf("%T: unexpected error: %v", input, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%T: expected %+v, got %+v", input, expected, actual)
+		}
+	}
+}
+
+func TestMakeEnumDataFromStruct_Errors(t *testing.T) {
+	type badOption struct {
+		ColorRed struct{} `enum:"name=red,colour=crimson"`
+	}
+	type notKeyValue struct {
+		ColorRed struct{} `enum:"red"`
+	}
+	notStruct := 42
+
+	type testCase struct {
+		Name     string
+		Input    interface{}
+		Expected string
+	}
+
+	testData := [...]testCase{
+		{"bad-option", badOption{}, `field ColorRed: enum tag: unknown option "colour"`},
+		{"not-key-value", notKeyValue{}, `field ColorRed: enum tag: option "red" is not of the form key=value`},
+		{"not-struct", notStruct, "expected struct or pointer to struct, got int"},
+		{"pointer-to-non-struct", &notStruct, "expected struct or pointer to struct, got *int"},
+		{"nil", nil, "expected struct or pointer to struct, got <nil>"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			data, err := MakeEnumDataFromStruct(row.Input)
+			if err == nil || !strings.Contains(err.Error(), row.Expected) {
+				t.Errorf("expected error %q, got %v", row.Expected, err)
+			}
+			if data != nil {
+				t.Errorf("expected nil data, got %+v", data)
+			}
+		})
+	}
+}
+
+func TestMakeBitfieldDataFromStruct(t *testing.T) {
+	type perms struct {
+		PermExec  struct{} `bitfield:"name=exec,alias=x"`
+		PermWrite struct{} `bitfield:"name=write,alias=w"`
+		PermRead  struct{} `bitfield:"name=read,alias=r,index=2"`
+		PermSkip  struct{} `bitfield:"-"`
+		PermSuid  struct{} `bitfield:"index=5"`
+		unused    struct{}
+	}
+
+	expected := []BitfieldData{
+		{GoName: "PermExec", Name: "exec", Aliases: []string{"x"}},
+		{GoName: "PermWrite", Name: "write", Aliases: []string{"w"}},
+		{GoName: "PermRead", Name: "read", Aliases: []string{"r"}},
+		{},
+		{},
+		{GoName: "PermSuid", Name: "PermSuid"},
+	}
+
+	for _, input := range []interface{}{perms{}, &perms{}} {
+		actual, err := MakeBitfieldDataFromStruct(input)
+		if err != nil {
+			t.Errorf("%T: unexpected error: %v", input, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%T: expected %+v, got %+v", input, expected, actual)
+		}
+	}
+
+	data, err := MakeBitfieldDataFromStruct(perms{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	perm := MakeBitfieldType("Perm", data)
+	if value, err := perm.FromString("exec|read|PermSuid"); err != nil || value != 0x25 {
+		t.Errorf("FromString: expected 0x25, nil; got 0x%x, %v", value, err)
+	}
+}
+
+func TestMakeBitfieldDataFromStruct_Errors(t *testing.T) {
+	type badOption struct {
+		PermExec struct{} `bitfield:"name=exec,bit=0"`
+	}
+	type badIndex struct {
+		PermExec struct{} `bitfield:"index=zero"`
+	}
+	type indexTooLarge struct {
+		PermExec struct{} `bitfield:"index=64"`
+	}
+	type indexReused struct {
+		PermExec  struct{} `bitfield:"index=1"`
+		PermWrite struct{} `bitfield:"index=1"`
+	}
+	notStruct := "perm"
+
+	type testCase struct {
+		Name     string
+		Input    interface{}
+		Expected string
+	}
+
+	testData := [...]testCase{
+		{"bad-option", badOption{}, `field PermExec: bitfield tag: unknown option "bit"`},
+		{"bad-index", badIndex{}, "field PermExec: bitfield tag: invalid index:"},
+		{"index-too-large", indexTooLarge{}, "field PermExec: bitfield tag: index 64 must be < 64"},
+		{"index-reused", indexReused{}, "field PermWrite: bitfield tag: index 1 is already used by field PermExec"},
+		{"not-struct", notStruct, "expected struct or pointer to struct, got string"},
+		{"pointer-to-non-struct", &notStruct, "expected struct or pointer to struct, got *string"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			data, err := MakeBitfieldDataFromStruct(row.Input)
+			if err == nil || !strings.Contains(err.Error(), row.Expected) {
+				t.Errorf("expected error %q, got %v", row.Expected, err)
+			}
+			if data != nil {
+				t.Errorf("expected nil data, got %+v", data)
+			}
+		})
+	}
+}
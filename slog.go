@@ -0,0 +1,40 @@
+//go:build go1.21
+// +build go1.21
+
+package enumhelper
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+// ToSlogAttr returns a slog.Attr with the given key and the string
+// representation of the given enum value.
+func (enum EnumType) ToSlogAttr(key string, value uint) slog.Attr {
+	return slog.String(key, enum.ToString(value))
+}
+
+// ToSlogAttr returns a slog.Attr with the given key and the string
+// representation of the given bitfield value.
+func (bitfield BitfieldType) ToSlogAttr(key string, value uint64) slog.Attr {
+	return slog.String(key, bitfield.ToString(value))
+}
+
+// ToSlogGroup returns a slog.Attr for a group with the given key that
+// describes the given bitfield value in detail: "hex" holds the value in
+// hexadecimal, "bits" holds the canonical names (Name, or GoName if Name is
+// empty) of the named bits that are set, and "raw" holds the numeric value.
+func (bitfield BitfieldType) ToSlogGroup(key string, value uint64) slog.Attr {
+	names := make([]string, 0, 64)
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		if name := data.canonicalName(); (value&data.Bit) != 0 && name != "" {
+			names = append(names, name)
+		}
+	})
+	return slog.Group(
+		key,
+		slog.String("hex", "0x"+strconv.FormatUint(value, 16)),
+		slog.Any("bits", names),
+		slog.Uint64("raw", value),
+	)
+}
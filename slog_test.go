@@ -0,0 +1,82 @@
+//go:build go1.21
+// +build go1.21
+
+package enumhelper
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordAttrs adds the given attribute to a fresh slog.Record and returns
+// the attributes that the record reports back.
+func recordAttrs(attr slog.Attr) []slog.Attr {
+	r := slog.NewRecord(time.Time{}, slog.LevelInfo, "test", 0)
+	r.AddAttrs(attr)
+	var out []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		out = append(out, a)
+		return true
+	})
+	return out
+}
+
+func TestEnumType_ToSlogAttr(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	attrs := recordAttrs(color.ToSlogAttr("color", 1))
+	if len(attrs) != 1 {
+		t.Fatalf("expected 1 attr, got %d", len(attrs))
+	}
+	if attrs[0].Key != "color" {
+		t.Errorf("expected key %q, got %q", "color", attrs[0].Key)
+	}
+	if attrs[0].Value.Kind() != slog.KindString || attrs[0].Value.String() != "green" {
+		t.Errorf("expected string value %q, got %v", "green", attrs[0].Value)
+	}
+}
+
+func TestBitfieldType_ToSlogAttr(t *testing.T) {
+	perm := MakeBitfieldType("Perm", testPermData)
+
+	attrs := recordAttrs(perm.ToSlogAttr("perm", 0x5))
+	if len(attrs) != 1 {
+		t.Fatalf("expected 1 attr, got %d", len(attrs))
+	}
+	if attrs[0].Key != "perm" {
+		t.Errorf("expected key %q, got %q", "perm", attrs[0].Key)
+	}
+	if expected := perm.ToString(0x5); attrs[0].Value.Kind() != slog.KindString || attrs[0].Value.String() != expected {
+		t.Errorf("expected string value %q, got %v", expected, attrs[0].Value)
+	}
+}
+
+func TestBitfieldType_ToSlogGroup(t *testing.T) {
+	perm := MakeBitfieldType("Perm", []BitfieldData{
+		{GoName: "PermExec", Name: "exec"},
+		{GoName: "PermWrite"},
+		{GoName: "PermRead", Name: "read"},
+	})
+
+	attrs := recordAttrs(perm.ToSlogGroup("perm", 0x7))
+	if len(attrs) != 1 || attrs[0].Key != "perm" || attrs[0].Value.Kind() != slog.KindGroup {
+		t.Fatalf("expected one group attr named perm, got %v", attrs)
+	}
+
+	fields := make(map[string]slog.Value)
+	for _, attr := range attrs[0].Value.Group() {
+		fields[attr.Key] = attr.Value
+	}
+	if hex := fields["hex"].String(); hex != "0x7" {
+		t.Errorf("hex: expected %q, got %q", "0x7", hex)
+	}
+	if raw := fields["raw"].Uint64(); raw != 0x7 {
+		t.Errorf("raw: expected 7, got %d", raw)
+	}
+	bits, _ := fields["bits"].Any().([]string)
+	if strings.Join(bits, "|") != "exec|PermWrite|read" {
+		t.Errorf("bits: expected [exec PermWrite read], got %v", fields["bits"])
+	}
+}
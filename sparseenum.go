@@ -0,0 +1,240 @@
+package enumhelper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SparseEnumData holds data about one particular value of a sparse enum.
+type SparseEnumData struct {
+	EnumData
+
+	// Value is the numeric value of this enum value.
+	Value int64
+}
+
+// SparseEnumType holds data about an enum type whose values need not be
+// contiguous, such as HTTP status codes or errno values.  Values may be
+// negative.
+type SparseEnumType struct {
+	// Type gives the Go name for this enum type.
+	Type string
+
+	// Data lists the data for all known values, in order of increasing
+	// value.
+	Data []*SparseEnumData
+
+	// Names holds the canonical names for all known values, in order of
+	// increasing value.
+	Names []string
+
+	// ByName maps valid names to the data for the corresponding value.
+	ByName map[string]*SparseEnumData
+
+	// ByValue maps known numeric values to their data.
+	ByValue map[int64]*SparseEnumData
+
+	allowed []string
+}
+
+// MakeSparseEnumType initializes and returns a SparseEnumType.
+//
+// If two values share a name, the name refers to the lower value.
+func MakeSparseEnumType(typeName string, in map[int64]EnumData) SparseEnumType {
+	length := uint(len(in))
+
+	out := SparseEnumType{
+		Type:    typeName,
+		Data:    make([]*SparseEnumData, 0, length),
+		Names:   make([]string, 0, length),
+		ByName:  make(map[string]*SparseEnumData, 4*length),
+		ByValue: make(map[int64]*SparseEnumData, length),
+		allowed: make([]string, 0, length),
+	}
+
+	for value, data := range in {
+		out.Data = append(out.Data, &SparseEnumData{
+			EnumData: data,
+			Value:    value,
+		})
+	}
+	sort.Slice(out.Data, func(i, j int) bool {
+		return out.Data[i].Value < out.Data[j].Value
+	})
+
+	addName := func(name string, ptr *SparseEnumData) {
+		if _, found := out.ByName[name]; !found {
+			out.ByName[name] = ptr
+		}
+	}
+
+	for _, ptr := range out.Data {
+		out.Names = append(out.Names, ptr.Name)
+		out.ByValue[ptr.Value] = ptr
+		if !ptr.Deprecated {
+			out.allowed = append(out.allowed, ptr.Name)
+		}
+
+		if ptr.Name != "" {
+			addName(ptr.Name, ptr)
+			addName(strings.ToLower(ptr.Name), ptr)
+		}
+
+		if ptr.GoName != "" {
+			addName(ptr.GoName, ptr)
+			addName(strings.ToLower(ptr.GoName), ptr)
+		}
+
+		for _, alias := range ptr.Aliases {
+			addName(alias, ptr)
+			addName(strings.ToLower(alias), ptr)
+		}
+	}
+	return out
+}
+
+// Contains returns true iff value is a known value of this enum type.
+func (enum SparseEnumType) Contains(value int64) bool {
+	_, found := enum.ByValue[value]
+	return found
+}
+
+// ForEach iterates over enum.Data with the given callback function.
+func (enum SparseEnumType) ForEach(fn func(data SparseEnumData)) {
+	for _, ptr := range enum.Data {
+		fn(*ptr)
+	}
+}
+
+// ToGoString generates a Go string representation for the given enum value.
+func (enum SparseEnumType) ToGoString(value int64) string {
+	if data, found := enum.ByValue[value]; found && data.GoName != "" {
+		return data.GoName
+	}
+	return enum.Type + "(" + strconv.FormatInt(value, 10) + ")"
+}
+
+// GoString is an alias for ToGoString.
+func (enum SparseEnumType) GoString(value int64) string {
+	return enum.ToGoString(value)
+}
+
+// ToString generates a string representation for the given enum value.
+func (enum SparseEnumType) ToString(value int64) string {
+	if data, found := enum.ByValue[value]; found && data.Name != "" {
+		return data.Name
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+// String is an alias for ToString.
+func (enum SparseEnumType) String(value int64) string {
+	return enum.ToString(value)
+}
+
+// ToJSON marshals this enum value to JSON.  Known values without a name are
+// marshaled as JSON numbers.  Returns UnknownEnumValueError if the enum value
+// is not known.
+func (enum SparseEnumType) ToJSON(value int64) ([]byte, error) {
+	data, found := enum.ByValue[value]
+	if !found {
+		return nil, UnknownEnumValueError{
+			Type:  enum.Type,
+			Value: value,
+		}
+	}
+	if data.Name == "" {
+		return json.Marshal(value)
+	}
+	if data.JSON == nil {
+		return json.Marshal(data.Name)
+	}
+	return data.JSON, nil
+}
+
+// FromString parses the string representation of an enum value.  It accepts
+// any name of a known value, a known value's number, or either of those
+// wrapped in the "Type(...)" form produced by ToGoString.  Returns
+// InvalidEnumNameError if the string cannot be parsed, or
+// UnknownEnumValueError if it holds a number which is not a known value.
+func (enum SparseEnumType) FromString(str string) (int64, error) {
+	strPrefix := enum.Type + "("
+	strSuffix := ")"
+	if strings.HasPrefix(str, strPrefix) && strings.HasSuffix(str, strSuffix) {
+		i := uint(len(strPrefix))
+		j := uint(len(str)) - uint(len(strSuffix))
+		str = str[i:j]
+	}
+
+	if data, found := enum.ByName[str]; found {
+		return data.Value, nil
+	}
+
+	if data, found := enum.ByName[strings.ToLower(str)]; found {
+		return data.Value, nil
+	}
+
+	if i64, err := strconv.ParseInt(str, 0, 64); err == nil {
+		return enum.checkValue(i64)
+	}
+
+	return 0, InvalidEnumNameError{
+		Type:    enum.Type,
+		Name:    str,
+		Allowed: enum.allowed,
+	}
+}
+
+// Parse is an alias for FromString.
+func (enum SparseEnumType) Parse(str string) (int64, error) {
+	return enum.FromString(str)
+}
+
+// FromJSON unmarshals an enum value from JSON.  The JSON value may be a
+// string or a known numeric value.  Returns IsNullError,
+// InvalidEnumNameError, or UnknownEnumValueError if a JSON value was parsed
+// but could not be unmarshaled as an enum value.  Panics if raw is nil.
+func (enum SparseEnumType) FromJSON(raw []byte) (int64, error) {
+	if raw == nil {
+		panic(errors.New("[]byte is nil"))
+	}
+
+	if bytes.Equal(raw, nullBytes) {
+		return 0, IsNullError{}
+	}
+
+	for _, data := range enum.Data {
+		if data.JSON != nil && bytes.Equal(raw, data.JSON) {
+			return data.Value, nil
+		}
+	}
+
+	var str string
+	err0 := json.Unmarshal(raw, &str)
+	if err0 == nil {
+		return enum.FromString(str)
+	}
+
+	var i64 int64
+	err1 := json.Unmarshal(raw, &i64)
+	if err1 == nil {
+		return enum.checkValue(i64)
+	}
+
+	return 0, err0
+}
+
+// checkValue returns value, or UnknownEnumValueError if value is not known.
+func (enum SparseEnumType) checkValue(value int64) (int64, error) {
+	if !enum.Contains(value) {
+		return 0, UnknownEnumValueError{
+			Type:  enum.Type,
+			Value: value,
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,89 @@
+package enumhelper
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSparseEnumType_UnknownValue(t *testing.T) {
+	status := MakeSparseEnumType("Status", map[int64]EnumData{
+		200: {GoName: "StatusOK", Name: "ok"},
+		404: {GoName: "StatusNotFound", Name: "not_found"},
+	})
+
+	var x UnknownEnumValueError
+	if _, err := status.ToJSON(500); !errors.As(err, &x) || x.Value != 500 {
+		t.Errorf("ToJSON(500): expected UnknownEnumValueError with Value 500, got %#v", err)
+	}
+	if _, err := status.FromJSON([]byte(`-1`)); !errors.As(err, &x) || x.Value != -1 {
+		t.Errorf("FromJSON(-1): expected UnknownEnumValueError with Value -1, got %#v", err)
+	}
+	if _, err := status.FromJSON([]byte(`"teapot"`)); !errors.Is(err, ErrInvalidEnumName) {
+		t.Errorf("FromJSON(teapot): expected %v, got %v", ErrInvalidEnumName, err)
+	}
+	if value, err := status.FromJSON([]byte(`404`)); err != nil || value != 404 {
+		t.Errorf("FromJSON(404): expected 404, nil; got %d, %v", value, err)
+	}
+}
+
+func TestSparseEnumType_RoundTrip(t *testing.T) {
+	errno := MakeSparseEnumType("Errno", map[int64]EnumData{
+		-1:   {GoName: "ErrnoUnknown", Name: "unknown"},
+		0:    {GoName: "ErrnoNone"},
+		2:    {GoName: "ErrnoNoEnt", Name: "ENOENT", Aliases: []string{"not_found"}},
+		13:   {GoName: "ErrnoAccess", Name: "EACCES", JSON: []byte(`"denied"`)},
+		1000: {GoName: "ErrnoBig", Name: "big"},
+	})
+
+	for _, data := range errno.Data {
+		value := data.Value
+
+		str := errno.ToString(value)
+		if actual, err := errno.FromString(str); err != nil || actual != value {
+			t.Errorf("FromString(ToString(%d) = %q): expected %d, nil; got %d, %v", value, str, value, actual, err)
+		}
+
+		goStr := errno.ToGoString(value)
+		if actual, err := errno.FromString(goStr); err != nil || actual != value {
+			t.Errorf("FromString(ToGoString(%d) = %q): expected %d, nil; got %d, %v", value, goStr, value, actual, err)
+		}
+
+		raw, err := errno.ToJSON(value)
+		if err != nil {
+			t.Errorf("ToJSON(%d): unexpected error: %v", value, err)
+			continue
+		}
+		if actual, err := errno.FromJSON(raw); err != nil || actual != value {
+			t.Errorf("FromJSON(ToJSON(%d) = %s): expected %d, nil; got %d, %v", value, raw, value, actual, err)
+		}
+	}
+
+	if raw, err := errno.ToJSON(0); err != nil || string(raw) != "0" {
+		t.Errorf("ToJSON(0): expected 0, nil; got %s, %v", raw, err)
+	}
+
+	type testCase struct {
+		Input string
+		Value int64
+		Err   error
+	}
+
+	testData := [...]testCase{
+		{"2", 2, nil},
+		{"-1", -1, nil},
+		{"0x3e8", 1000, nil},
+		{"Errno(13)", 13, nil},
+		{"Errno(-1)", -1, nil},
+		{"Errno(not_found)", 2, nil},
+		{"7", 0, ErrUnknownEnumValue},
+		{"Errno(7)", 0, ErrUnknownEnumValue},
+		{"Errno(bogus)", 0, ErrInvalidEnumName},
+	}
+
+	for _, row := range testData {
+		value, err := errno.FromString(row.Input)
+		if !errors.Is(err, row.Err) || value != row.Value {
+			t.Errorf("FromString(%q): expected %d, %v; got %d, %v", row.Input, row.Value, row.Err, value, err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package enumhelper
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// ScanEnum converts a value read from a database column into an enum value,
+// for use in implementing sql.Scanner.  The column may hold the enum's string
+// representation (as string or []byte) or its numeric value (as int64).
+// Returns IsNullError if src is nil, or the error returned by the
+// WithValidationHook function.
+func (enum EnumType) ScanEnum(src interface{}, dst *uint) error {
+	var value uint
+	switch x := src.(type) {
+	case nil:
+		return IsNullError{}
+	case string:
+		u, err := enum.lookup(x)
+		if err != nil {
+			return err
+		}
+		value = u
+	case []byte:
+		u, err := enum.lookup(string(x))
+		if err != nil {
+			return err
+		}
+		value = u
+	case int64:
+		if x < 0 || !enum.Contains(uint(x)) {
+			return InvalidEnumValueError{
+				Type:  enum.Type,
+				Value: uint(x),
+				Limit: enum.Len(),
+			}
+		}
+		value = uint(x)
+	default:
+		return fmt.Errorf("cannot scan %T into %s", src, enum.Type)
+	}
+	value, err := enum.validate(value)
+	if err != nil {
+		return err
+	}
+	*dst = value
+	return nil
+}
+
+// ValueEnum converts an enum value into its string representation for
+// storage in a database column, for use in implementing driver.Valuer.
+// Returns InvalidEnumValueError if the enum value is out of range.
+func (enum EnumType) ValueEnum(value uint) (driver.Value, error) {
+	if !enum.Contains(value) {
+		return nil, InvalidEnumValueError{
+			Type:  enum.Type,
+			Value: value,
+			Limit: enum.Len(),
+		}
+	}
+	return enum.ToString(value), nil
+}
+
+// ScanBitfield converts a value read from a database column into a bitfield
+// value, for use in implementing sql.Scanner.  The column may hold the
+// bitfield's numeric value (as int64, reinterpreted as uint64) or its string
+// representation (as string or []byte).  Numeric values are subject to the
+// UnknownBitMode, as with FromJSON.  Returns IsNullError if src is nil,
+// InvalidBitfieldNameError if the value cannot be parsed, or the error
+// returned by the WithValidationHook function.
+func (bitfield BitfieldType) ScanBitfield(src interface{}, dst *uint64) error {
+	var value uint64
+	switch x := src.(type) {
+	case nil:
+		return IsNullError{}
+	case int64:
+		u64, ok := bitfield.checkUnknownBits(uint64(x))
+		if !ok {
+			return InvalidBitfieldNameError{
+				Type:    bitfield.Type,
+				Name:    strconv.FormatUint(uint64(x), 10),
+				Allowed: bitfield.Names,
+			}
+		}
+		u, err := bitfield.validate(u64)
+		if err != nil {
+			return err
+		}
+		value = u
+	case string:
+		u, err := bitfield.FromString(x)
+		if err != nil {
+			return err
+		}
+		value = u
+	case []byte:
+		u, err := bitfield.FromString(string(x))
+		if err != nil {
+			return err
+		}
+		value = u
+	default:
+		return fmt.Errorf("cannot scan %T into %s", src, bitfield.Type)
+	}
+	*dst = value
+	return nil
+}
+
+// ValueBitfield converts a bitfield value into an int64 for storage in a
+// database column, for use in implementing driver.Valuer.  The value is
+// reinterpreted as int64 because driver.Value does not allow uint64.
+func (bitfield BitfieldType) ValueBitfield(value uint64) (driver.Value, error) {
+	return int64(value), nil
+}
@@ -0,0 +1,998 @@
+package enumhelper
+
+import (
+	"database/sql/driver"
+	"flag"
+	"io"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// type SyncEnumType {{{
+
+// SyncEnumType wraps an EnumType with a sync.RWMutex, so that the type can be
+// replaced with Store while other goroutines are using it.  It has the same
+// methods as EnumType, except for WithRWMutex.  Most methods hold a read lock
+// while they delegate to the EnumType method of the same name; RegisterAlias,
+// DeregisterAlias, and Freeze hold the write lock.  Methods which call back
+// into the caller, such as methods which run the WithValidationHook or
+// WithColor functions, or which read or write an io stream, instead delegate
+// to the EnumType returned by Load, so that they do not hold the lock while
+// the caller's code runs.  Methods which return a new EnumType return a
+// new SyncEnumType wrapping it.
+type SyncEnumType struct {
+	mu   sync.RWMutex
+	enum EnumType
+}
+
+// WithRWMutex returns a SyncEnumType which wraps a copy of this enum type.
+func (enum EnumType) WithRWMutex() *SyncEnumType {
+	return &SyncEnumType{enum: enum}
+}
+
+// Load returns a copy of the wrapped EnumType.
+func (s *SyncEnumType) Load() EnumType {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum
+}
+
+// Store replaces the wrapped EnumType.
+func (s *SyncEnumType) Store(enum EnumType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enum = enum
+}
+
+// Get is like EnumType.Get.
+func (s *SyncEnumType) Get(value uint) AnnotatedEnumData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.Get(value)
+}
+
+// ActiveValues is like EnumType.ActiveValues.
+func (s *SyncEnumType) ActiveValues() []AnnotatedEnumData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.ActiveValues()
+}
+
+// Validate is like EnumType.Validate.
+func (s *SyncEnumType) Validate(value uint) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.Validate(value)
+}
+
+// DescribeValue is like EnumType.DescribeValue.
+func (s *SyncEnumType) DescribeValue(value uint) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.DescribeValue(value)
+}
+
+// Len is like EnumType.Len.
+func (s *SyncEnumType) Len() uint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.Len()
+}
+
+// Contains is like EnumType.Contains.
+func (s *SyncEnumType) Contains(value uint) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.Contains(value)
+}
+
+// ForEach is like EnumType.ForEach.
+func (s *SyncEnumType) ForEach(fn func(data AnnotatedEnumData)) {
+	s.Load().ForEach(fn)
+}
+
+// Traverse is like EnumType.Traverse.
+func (s *SyncEnumType) Traverse(start uint, fn func(value uint, data EnumData) bool) {
+	s.Load().Traverse(start, fn)
+}
+
+// ForEachInRange is like EnumType.ForEachInRange.
+func (s *SyncEnumType) ForEachInRange(lo, hi uint, fn func(value uint, data EnumData)) {
+	s.Load().ForEachInRange(lo, hi, fn)
+}
+
+// ToGoString is like EnumType.ToGoString.
+func (s *SyncEnumType) ToGoString(value uint) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.ToGoString(value)
+}
+
+// GoString is an alias for ToGoString.
+func (s *SyncEnumType) GoString(value uint) string {
+	return s.ToGoString(value)
+}
+
+// ToString is like EnumType.ToString.
+func (s *SyncEnumType) ToString(value uint) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.ToString(value)
+}
+
+// String is an alias for ToString.
+func (s *SyncEnumType) String(value uint) string {
+	return s.ToString(value)
+}
+
+// ToJSON is like EnumType.ToJSON.
+func (s *SyncEnumType) ToJSON(value uint) ([]byte, error) {
+	return s.Load().ToJSON(value)
+}
+
+// FromString is like EnumType.FromString.
+func (s *SyncEnumType) FromString(str string) (uint, error) {
+	return s.Load().FromString(str)
+}
+
+// Parse is an alias for FromString.
+func (s *SyncEnumType) Parse(str string) (uint, error) {
+	return s.FromString(str)
+}
+
+// MustParse is like EnumType.MustParse.
+func (s *SyncEnumType) MustParse(str string) uint {
+	return s.Load().MustParse(str)
+}
+
+// ParseOrZero is like EnumType.ParseOrZero.
+func (s *SyncEnumType) ParseOrZero(str string) uint {
+	return s.Load().ParseOrZero(str)
+}
+
+// ParseOrMax is like EnumType.ParseOrMax.
+func (s *SyncEnumType) ParseOrMax(str string) uint {
+	return s.Load().ParseOrMax(str)
+}
+
+// ParseStrict is like EnumType.ParseStrict.
+func (s *SyncEnumType) ParseStrict(str string) (uint, error) {
+	return s.Load().ParseStrict(str)
+}
+
+// MakeResolver is like EnumType.MakeResolver.  The returned function calls
+// FromString, so it uses whichever EnumType was most recently stored.
+func (s *SyncEnumType) MakeResolver() func(string) (uint, bool) {
+	return func(str string) (uint, bool) {
+		value, err := s.FromString(str)
+		if err != nil {
+			return 0, false
+		}
+		return value, true
+	}
+}
+
+// MakePredicate is like EnumType.MakePredicate.
+func (s *SyncEnumType) MakePredicate(value uint) func(uint) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.MakePredicate(value)
+}
+
+// MakeRangePredicate is like EnumType.MakeRangePredicate.
+func (s *SyncEnumType) MakeRangePredicate(min, max uint) func(uint) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.MakeRangePredicate(min, max)
+}
+
+// MakeConstMap is like EnumType.MakeConstMap.
+func (s *SyncEnumType) MakeConstMap() map[string]uint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.MakeConstMap()
+}
+
+// MakeReverseMap is like EnumType.MakeReverseMap.
+func (s *SyncEnumType) MakeReverseMap() map[uint]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.MakeReverseMap()
+}
+
+// FromJSON is like EnumType.FromJSON.
+func (s *SyncEnumType) FromJSON(raw []byte) (uint, error) {
+	return s.Load().FromJSON(raw)
+}
+
+// MustFromJSON is like EnumType.MustFromJSON.
+func (s *SyncEnumType) MustFromJSON(raw []byte) uint {
+	return s.Load().MustFromJSON(raw)
+}
+
+// WithEncodedAs is like EnumType.WithEncodedAs.
+func (s *SyncEnumType) WithEncodedAs(format string) *SyncEnumType {
+	return s.Load().WithEncodedAs(format).WithRWMutex()
+}
+
+// WithValidationHook is like EnumType.WithValidationHook.
+func (s *SyncEnumType) WithValidationHook(fn func(value uint) error) *SyncEnumType {
+	return s.Load().WithValidationHook(fn).WithRWMutex()
+}
+
+// WithColor is like EnumType.WithColor.
+func (s *SyncEnumType) WithColor(fn func(value uint) string) *SyncEnumType {
+	return s.Load().WithColor(fn).WithRWMutex()
+}
+
+// ToColorString is like EnumType.ToColorString.
+func (s *SyncEnumType) ToColorString(value uint) string {
+	return s.Load().ToColorString(value)
+}
+
+// WithI18N is like EnumType.WithI18N.
+func (s *SyncEnumType) WithI18N(fn func(value uint, lang string) string) *SyncEnumType {
+	return s.Load().WithI18N(fn).WithRWMutex()
+}
+
+// ToLocalizedString is like EnumType.ToLocalizedString.
+func (s *SyncEnumType) ToLocalizedString(value uint, lang string) string {
+	return s.Load().ToLocalizedString(value, lang)
+}
+
+// WithDisplayName is like EnumType.WithDisplayName.
+func (s *SyncEnumType) WithDisplayName(fn func(value uint) string) *SyncEnumType {
+	return s.Load().WithDisplayName(fn).WithRWMutex()
+}
+
+// ToDisplayString is like EnumType.ToDisplayString.
+func (s *SyncEnumType) ToDisplayString(value uint) string {
+	return s.Load().ToDisplayString(value)
+}
+
+// WithValueIcon is like EnumType.WithValueIcon.
+func (s *SyncEnumType) WithValueIcon(fn func(value uint) string) *SyncEnumType {
+	return s.Load().WithValueIcon(fn).WithRWMutex()
+}
+
+// ToIconString is like EnumType.ToIconString.
+func (s *SyncEnumType) ToIconString(value uint) string {
+	return s.Load().ToIconString(value)
+}
+
+// RegisterAlias is like EnumType.RegisterAlias.
+func (s *SyncEnumType) RegisterAlias(value uint, alias string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enum.RegisterAlias(value, alias)
+}
+
+// DeregisterAlias is like EnumType.DeregisterAlias.
+func (s *SyncEnumType) DeregisterAlias(alias string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enum.DeregisterAlias(alias)
+}
+
+// Freeze is like EnumType.Freeze.
+func (s *SyncEnumType) Freeze() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enum.Freeze()
+}
+
+// WithMaxReadSize is like EnumType.WithMaxReadSize.
+func (s *SyncEnumType) WithMaxReadSize(n int64) *SyncEnumType {
+	return s.Load().WithMaxReadSize(n).WithRWMutex()
+}
+
+// ParseFromReader is like EnumType.ParseFromReader.
+func (s *SyncEnumType) ParseFromReader(r io.Reader) (uint, error) {
+	return s.Load().ParseFromReader(r)
+}
+
+// AllAliases is like EnumType.AllAliases.
+func (s *SyncEnumType) AllAliases(value uint) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.AllAliases(value)
+}
+
+// AddValue is like EnumType.AddValue.
+func (s *SyncEnumType) AddValue(data EnumData) (*SyncEnumType, error) {
+	enum, err := s.Load().AddValue(data)
+	if err != nil {
+		return nil, err
+	}
+	return enum.WithRWMutex(), nil
+}
+
+// ExtendWith is like EnumType.ExtendWith.
+func (s *SyncEnumType) ExtendWith(extra []EnumData) (*SyncEnumType, error) {
+	enum, err := s.Load().ExtendWith(extra)
+	if err != nil {
+		return nil, err
+	}
+	return enum.WithRWMutex(), nil
+}
+
+// TruncateTo is like EnumType.TruncateTo.
+func (s *SyncEnumType) TruncateTo(n uint) (*SyncEnumType, error) {
+	enum, err := s.Load().TruncateTo(n)
+	if err != nil {
+		return nil, err
+	}
+	return enum.WithRWMutex(), nil
+}
+
+// MarshalCUE is like EnumType.MarshalCUE.
+func (s *SyncEnumType) MarshalCUE(typeName string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.MarshalCUE(typeName)
+}
+
+// Diff is like EnumType.Diff.
+func (s *SyncEnumType) Diff(other EnumType) EnumTypeDiff {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.Diff(other)
+}
+
+// ApplyDiff is like EnumType.ApplyDiff.
+func (s *SyncEnumType) ApplyDiff(diff EnumTypeDiff) (*SyncEnumType, error) {
+	enum, err := s.Load().ApplyDiff(diff)
+	if err != nil {
+		return nil, err
+	}
+	return enum.WithRWMutex(), nil
+}
+
+// MakeEnumSetType is like EnumType.MakeEnumSetType.
+func (s *SyncEnumType) MakeEnumSetType() (BitfieldType, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.MakeEnumSetType()
+}
+
+type syncEnumTypeFlag struct {
+	s   *SyncEnumType
+	ptr *uint
+}
+
+// NewFlag is like EnumType.NewFlag.  Each use of the flag parses with whichever
+// EnumType was most recently stored.
+func (s *SyncEnumType) NewFlag(ptr *uint) flag.Value {
+	return &syncEnumTypeFlag{s: s, ptr: ptr}
+}
+
+func (f *syncEnumTypeFlag) String() string {
+	if f == nil || f.ptr == nil {
+		return ""
+	}
+	return f.s.ToString(*f.ptr)
+}
+
+func (f *syncEnumTypeFlag) Set(str string) error {
+	return f.s.Load().NewFlag(f.ptr).Set(str)
+}
+
+func (f *syncEnumTypeFlag) Get() interface{} {
+	return *f.ptr
+}
+
+var _ flag.Getter = (*syncEnumTypeFlag)(nil)
+
+// FlagUsage is like EnumType.FlagUsage.
+func (s *SyncEnumType) FlagUsage() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.FlagUsage()
+}
+
+// CSVHeader is like EnumType.CSVHeader.
+func (s *SyncEnumType) CSVHeader() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.CSVHeader()
+}
+
+// MarshalCSVRecord is like EnumType.MarshalCSVRecord.
+func (s *SyncEnumType) MarshalCSVRecord(value uint) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.MarshalCSVRecord(value)
+}
+
+// UnmarshalCSVRecord is like EnumType.UnmarshalCSVRecord.
+func (s *SyncEnumType) UnmarshalCSVRecord(record []string) (uint, error) {
+	return s.Load().UnmarshalCSVRecord(record)
+}
+
+// MarshalNDJSON is like EnumType.MarshalNDJSON.
+func (s *SyncEnumType) MarshalNDJSON(values []uint, w io.Writer) error {
+	return s.Load().MarshalNDJSON(values, w)
+}
+
+// UnmarshalNDJSON is like EnumType.UnmarshalNDJSON.
+func (s *SyncEnumType) UnmarshalNDJSON(r io.Reader) ([]uint, error) {
+	return s.Load().UnmarshalNDJSON(r)
+}
+
+// MarshalProtoJSON is like EnumType.MarshalProtoJSON.
+func (s *SyncEnumType) MarshalProtoJSON(value uint) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.MarshalProtoJSON(value)
+}
+
+// WriteOpenAPIComponent is like EnumType.WriteOpenAPIComponent.
+func (s *SyncEnumType) WriteOpenAPIComponent(w io.Writer) error {
+	return s.Load().WriteOpenAPIComponent(w)
+}
+
+// WriteProto is like EnumType.WriteProto.
+func (s *SyncEnumType) WriteProto(w io.Writer, packageName string) error {
+	return s.Load().WriteProto(w, packageName)
+}
+
+// WriteTypeScript is like EnumType.WriteTypeScript.
+func (s *SyncEnumType) WriteTypeScript(w io.Writer) error {
+	return s.Load().WriteTypeScript(w)
+}
+
+// WriteGraphQLEnum is like EnumType.WriteGraphQLEnum.
+func (s *SyncEnumType) WriteGraphQLEnum(w io.Writer) error {
+	return s.Load().WriteGraphQLEnum(w)
+}
+
+// WriteAvroSchema is like EnumType.WriteAvroSchema.
+func (s *SyncEnumType) WriteAvroSchema(w io.Writer) error {
+	return s.Load().WriteAvroSchema(w)
+}
+
+// UnmarshalProtoJSON is like EnumType.UnmarshalProtoJSON.
+func (s *SyncEnumType) UnmarshalProtoJSON(raw []byte) (uint, error) {
+	return s.Load().UnmarshalProtoJSON(raw)
+}
+
+// ScanEnum is like EnumType.ScanEnum.
+func (s *SyncEnumType) ScanEnum(src interface{}, dst *uint) error {
+	return s.Load().ScanEnum(src, dst)
+}
+
+// ValueEnum is like EnumType.ValueEnum.
+func (s *SyncEnumType) ValueEnum(value uint) (driver.Value, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.ValueEnum(value)
+}
+
+// MakeTestTable is like EnumType.MakeTestTable.
+func (s *SyncEnumType) MakeTestTable() []EnumTestCase {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.MakeTestTable()
+}
+
+// MakeTestTableWithAliases is like EnumType.MakeTestTableWithAliases.
+func (s *SyncEnumType) MakeTestTableWithAliases() []EnumInputTestCase {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.MakeTestTableWithAliases()
+}
+
+// Wrap is like EnumType.Wrap, applied to the EnumType returned by Load.
+// Later calls to Store do not affect the result.
+func (s *SyncEnumType) Wrap(value uint) EnumValue {
+	return s.Load().Wrap(value)
+}
+
+// ToYAML is like EnumType.ToYAML.
+func (s *SyncEnumType) ToYAML(value uint) (interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.ToYAML(value)
+}
+
+// FromYAML is like EnumType.FromYAML.
+func (s *SyncEnumType) FromYAML(node *yaml.Node) (uint, error) {
+	return s.Load().FromYAML(node)
+}
+
+// }}}
+
+// type SyncBitfieldType {{{
+
+// SyncBitfieldType wraps a BitfieldType with a sync.RWMutex, so that the type
+// can be replaced with Store while other goroutines are using it.  It has the
+// same methods as BitfieldType, except for WithRWMutex.  Most methods hold a
+// read lock while they delegate to the BitfieldType method of the same name;
+// RegisterAlias, DeregisterAlias, and Freeze hold the write lock.  Methods
+// which call back into the caller, such as methods which run the
+// WithValidationHook function, or which read or write an io stream, instead
+// delegate to the BitfieldType returned by Load, so that they do not hold the
+// lock while the caller's code runs.  Methods which return a new
+// BitfieldType return a new SyncBitfieldType wrapping it.
+type SyncBitfieldType struct {
+	mu       sync.RWMutex
+	bitfield BitfieldType
+}
+
+// WithRWMutex returns a SyncBitfieldType which wraps a copy of this bitfield
+// type.
+func (bitfield BitfieldType) WithRWMutex() *SyncBitfieldType {
+	return &SyncBitfieldType{bitfield: bitfield}
+}
+
+// Load returns a copy of the wrapped BitfieldType.
+func (s *SyncBitfieldType) Load() BitfieldType {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield
+}
+
+// Store replaces the wrapped BitfieldType.
+func (s *SyncBitfieldType) Store(bitfield BitfieldType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bitfield = bitfield
+}
+
+// Get is like BitfieldType.Get.
+func (s *SyncBitfieldType) Get(index uint) AnnotatedBitfieldData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.Get(index)
+}
+
+// ContainsBit is like BitfieldType.ContainsBit.
+func (s *SyncBitfieldType) ContainsBit(index uint) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.ContainsBit(index)
+}
+
+// ForEach is like BitfieldType.ForEach.
+func (s *SyncBitfieldType) ForEach(fn func(data AnnotatedBitfieldData)) {
+	s.Load().ForEach(fn)
+}
+
+// Traverse is like BitfieldType.Traverse.
+func (s *SyncBitfieldType) Traverse(startIndex uint, fn func(index uint, data AnnotatedBitfieldData) bool) {
+	s.Load().Traverse(startIndex, fn)
+}
+
+// DescribeBit is like BitfieldType.DescribeBit.
+func (s *SyncBitfieldType) DescribeBit(index uint) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.DescribeBit(index)
+}
+
+// ForEachInMask is like BitfieldType.ForEachInMask.
+func (s *SyncBitfieldType) ForEachInMask(mask uint64, fn func(data AnnotatedBitfieldData)) {
+	s.Load().ForEachInMask(mask, fn)
+}
+
+// AllAliasesForBit is like BitfieldType.AllAliasesForBit.
+func (s *SyncBitfieldType) AllAliasesForBit(index uint) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.AllAliasesForBit(index)
+}
+
+// SetBits is like BitfieldType.SetBits.
+func (s *SyncBitfieldType) SetBits(value uint64) []AnnotatedBitfieldData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.SetBits(value)
+}
+
+// ToGoString is like BitfieldType.ToGoString.
+func (s *SyncBitfieldType) ToGoString(value uint64) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.ToGoString(value)
+}
+
+// GoString is an alias for ToGoString.
+func (s *SyncBitfieldType) GoString(value uint64) string {
+	return s.ToGoString(value)
+}
+
+// ToString is like BitfieldType.ToString.
+func (s *SyncBitfieldType) ToString(value uint64) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.ToString(value)
+}
+
+// String is an alias for ToString.
+func (s *SyncBitfieldType) String(value uint64) string {
+	return s.ToString(value)
+}
+
+// ToJSON is like BitfieldType.ToJSON.
+func (s *SyncBitfieldType) ToJSON(value uint64) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.ToJSON(value)
+}
+
+// ToJSONArray is like BitfieldType.ToJSONArray.
+func (s *SyncBitfieldType) ToJSONArray(value uint64) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.ToJSONArray(value)
+}
+
+// WithEncodedAs is like BitfieldType.WithEncodedAs.
+func (s *SyncBitfieldType) WithEncodedAs(format string) *SyncBitfieldType {
+	return s.Load().WithEncodedAs(format).WithRWMutex()
+}
+
+// WithValidationHook is like BitfieldType.WithValidationHook.
+func (s *SyncBitfieldType) WithValidationHook(fn func(value uint64) error) *SyncBitfieldType {
+	return s.Load().WithValidationHook(fn).WithRWMutex()
+}
+
+// WithUnknownBitBehavior is like BitfieldType.WithUnknownBitBehavior.
+func (s *SyncBitfieldType) WithUnknownBitBehavior(mode UnknownBitMode) *SyncBitfieldType {
+	return s.Load().WithUnknownBitBehavior(mode).WithRWMutex()
+}
+
+// KnownBits is like BitfieldType.KnownBits.
+func (s *SyncBitfieldType) KnownBits() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.KnownBits()
+}
+
+// UnknownBits is like BitfieldType.UnknownBits.
+func (s *SyncBitfieldType) UnknownBits(value uint64) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.UnknownBits(value)
+}
+
+// IsFullyKnown is like BitfieldType.IsFullyKnown.
+func (s *SyncBitfieldType) IsFullyKnown(value uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.IsFullyKnown(value)
+}
+
+// PopCount is like BitfieldType.PopCount.
+func (s *SyncBitfieldType) PopCount(value uint64) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.PopCount(value)
+}
+
+// FromString is like BitfieldType.FromString.
+func (s *SyncBitfieldType) FromString(str string) (uint64, error) {
+	return s.Load().FromString(str)
+}
+
+// FromStringOrZero is like BitfieldType.FromStringOrZero.
+func (s *SyncBitfieldType) FromStringOrZero(str string) uint64 {
+	return s.Load().FromStringOrZero(str)
+}
+
+// FromStringOrMax is like BitfieldType.FromStringOrMax.
+func (s *SyncBitfieldType) FromStringOrMax(str string) uint64 {
+	return s.Load().FromStringOrMax(str)
+}
+
+// MustFromString is like BitfieldType.MustFromString.
+func (s *SyncBitfieldType) MustFromString(str string) uint64 {
+	return s.Load().MustFromString(str)
+}
+
+// MakeResolver is like BitfieldType.MakeResolver.  The returned function calls
+// FromString, so it uses whichever BitfieldType was most recently stored.
+func (s *SyncBitfieldType) MakeResolver() func(string) (uint64, bool) {
+	return func(str string) (uint64, bool) {
+		u64, err := s.FromString(str)
+		if err != nil {
+			return 0, false
+		}
+		return u64, true
+	}
+}
+
+// MakePredicate is like BitfieldType.MakePredicate.
+func (s *SyncBitfieldType) MakePredicate(mask uint64) func(uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.MakePredicate(mask)
+}
+
+// MakeAnyPredicate is like BitfieldType.MakeAnyPredicate.
+func (s *SyncBitfieldType) MakeAnyPredicate(mask uint64) func(uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.MakeAnyPredicate(mask)
+}
+
+// Set is like BitfieldType.Set.
+func (s *SyncBitfieldType) Set(value, bit uint64) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.Set(value, bit)
+}
+
+// Clear is like BitfieldType.Clear.
+func (s *SyncBitfieldType) Clear(value, bit uint64) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.Clear(value, bit)
+}
+
+// Toggle is like BitfieldType.Toggle.
+func (s *SyncBitfieldType) Toggle(value, bit uint64) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.Toggle(value, bit)
+}
+
+// HasAll is like BitfieldType.HasAll.
+func (s *SyncBitfieldType) HasAll(value, mask uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.HasAll(value, mask)
+}
+
+// HasAny is like BitfieldType.HasAny.
+func (s *SyncBitfieldType) HasAny(value, mask uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.HasAny(value, mask)
+}
+
+// MaskFor is like BitfieldType.MaskFor.
+func (s *SyncBitfieldType) MaskFor(names ...string) (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.MaskFor(names...)
+}
+
+// FromJSON is like BitfieldType.FromJSON.
+func (s *SyncBitfieldType) FromJSON(raw []byte) (uint64, error) {
+	return s.Load().FromJSON(raw)
+}
+
+// MustFromJSON is like BitfieldType.MustFromJSON.
+func (s *SyncBitfieldType) MustFromJSON(raw []byte) uint64 {
+	return s.Load().MustFromJSON(raw)
+}
+
+// MakeConstMap is like BitfieldType.MakeConstMap.
+func (s *SyncBitfieldType) MakeConstMap() map[string]uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.MakeConstMap()
+}
+
+// MakeReverseMap is like BitfieldType.MakeReverseMap.
+func (s *SyncBitfieldType) MakeReverseMap() map[uint64]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.MakeReverseMap()
+}
+
+// TruncateTo is like BitfieldType.TruncateTo.
+func (s *SyncBitfieldType) TruncateTo(n uint) (*SyncBitfieldType, error) {
+	bitfield, err := s.Load().TruncateTo(n)
+	if err != nil {
+		return nil, err
+	}
+	return bitfield.WithRWMutex(), nil
+}
+
+// AddBit is like BitfieldType.AddBit.
+func (s *SyncBitfieldType) AddBit(index uint, data BitfieldData) (*SyncBitfieldType, error) {
+	bitfield, err := s.Load().AddBit(index, data)
+	if err != nil {
+		return nil, err
+	}
+	return bitfield.WithRWMutex(), nil
+}
+
+// ExtendWith is like BitfieldType.ExtendWith.
+func (s *SyncBitfieldType) ExtendWith(extra []BitfieldData, startIndex uint) (*SyncBitfieldType, error) {
+	bitfield, err := s.Load().ExtendWith(extra, startIndex)
+	if err != nil {
+		return nil, err
+	}
+	return bitfield.WithRWMutex(), nil
+}
+
+// Intersection is like BitfieldType.Intersection.
+func (s *SyncBitfieldType) Intersection(other BitfieldType) *SyncBitfieldType {
+	return s.Load().Intersection(other).WithRWMutex()
+}
+
+// DumpBits is like BitfieldType.DumpBits.
+func (s *SyncBitfieldType) DumpBits(value uint64) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.DumpBits(value)
+}
+
+// RegisterAlias is like BitfieldType.RegisterAlias.
+func (s *SyncBitfieldType) RegisterAlias(index uint, alias string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bitfield.RegisterAlias(index, alias)
+}
+
+// DeregisterAlias is like BitfieldType.DeregisterAlias.
+func (s *SyncBitfieldType) DeregisterAlias(alias string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bitfield.DeregisterAlias(alias)
+}
+
+// Freeze is like BitfieldType.Freeze.
+func (s *SyncBitfieldType) Freeze() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bitfield.Freeze()
+}
+
+// WithMaxReadSize is like BitfieldType.WithMaxReadSize.
+func (s *SyncBitfieldType) WithMaxReadSize(n int64) *SyncBitfieldType {
+	return s.Load().WithMaxReadSize(n).WithRWMutex()
+}
+
+// ParseFromReader is like BitfieldType.ParseFromReader.
+func (s *SyncBitfieldType) ParseFromReader(r io.Reader) (uint64, error) {
+	return s.Load().ParseFromReader(r)
+}
+
+// FromJSONArray is like BitfieldType.FromJSONArray.
+func (s *SyncBitfieldType) FromJSONArray(raw []byte) (uint64, error) {
+	return s.Load().FromJSONArray(raw)
+}
+
+// Diff is like BitfieldType.Diff.
+func (s *SyncBitfieldType) Diff(other BitfieldType) BitfieldTypeDiff {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.Diff(other)
+}
+
+// ApplyDiff is like BitfieldType.ApplyDiff.
+func (s *SyncBitfieldType) ApplyDiff(diff BitfieldTypeDiff) (*SyncBitfieldType, error) {
+	bitfield, err := s.Load().ApplyDiff(diff)
+	if err != nil {
+		return nil, err
+	}
+	return bitfield.WithRWMutex(), nil
+}
+
+type syncBitfieldTypeFlag struct {
+	s   *SyncBitfieldType
+	ptr *uint64
+}
+
+// NewFlag is like BitfieldType.NewFlag.  Each use of the flag parses with
+// whichever BitfieldType was most recently stored.
+func (s *SyncBitfieldType) NewFlag(ptr *uint64) flag.Value {
+	return &syncBitfieldTypeFlag{s: s, ptr: ptr}
+}
+
+func (f *syncBitfieldTypeFlag) String() string {
+	if f == nil || f.ptr == nil {
+		return ""
+	}
+	return f.s.ToString(*f.ptr)
+}
+
+func (f *syncBitfieldTypeFlag) Set(str string) error {
+	return f.s.Load().NewFlag(f.ptr).Set(str)
+}
+
+func (f *syncBitfieldTypeFlag) Get() interface{} {
+	return *f.ptr
+}
+
+var _ flag.Getter = (*syncBitfieldTypeFlag)(nil)
+
+// FlagUsage is like BitfieldType.FlagUsage.
+func (s *SyncBitfieldType) FlagUsage() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.FlagUsage()
+}
+
+// MarshalNDJSON is like BitfieldType.MarshalNDJSON.
+func (s *SyncBitfieldType) MarshalNDJSON(values []uint64, w io.Writer) error {
+	return s.Load().MarshalNDJSON(values, w)
+}
+
+// UnmarshalNDJSON is like BitfieldType.UnmarshalNDJSON.
+func (s *SyncBitfieldType) UnmarshalNDJSON(r io.Reader) ([]uint64, error) {
+	return s.Load().UnmarshalNDJSON(r)
+}
+
+// ScanBitfield is like BitfieldType.ScanBitfield.
+func (s *SyncBitfieldType) ScanBitfield(src interface{}, dst *uint64) error {
+	return s.Load().ScanBitfield(src, dst)
+}
+
+// ValueBitfield is like BitfieldType.ValueBitfield.
+func (s *SyncBitfieldType) ValueBitfield(value uint64) (driver.Value, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.ValueBitfield(value)
+}
+
+// MakeTestTable is like BitfieldType.MakeTestTable.
+func (s *SyncBitfieldType) MakeTestTable() []BitfieldTestCase {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.MakeTestTable()
+}
+
+// Wrap is like BitfieldType.Wrap, applied to the BitfieldType returned by
+// Load.  Later calls to Store do not affect the result.
+func (s *SyncBitfieldType) Wrap(value uint64) BitfieldValue {
+	return s.Load().Wrap(value)
+}
+
+// ToYAML is like BitfieldType.ToYAML.
+func (s *SyncBitfieldType) ToYAML(value uint64) (interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.ToYAML(value)
+}
+
+// FromYAML is like BitfieldType.FromYAML.
+func (s *SyncBitfieldType) FromYAML(node *yaml.Node) (uint64, error) {
+	return s.Load().FromYAML(node)
+}
+
+// MarshalCUE is like BitfieldType.MarshalCUE.
+func (s *SyncBitfieldType) MarshalCUE(typeName string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.MarshalCUE(typeName)
+}
+
+// WriteAvroSchema is like BitfieldType.WriteAvroSchema.
+func (s *SyncBitfieldType) WriteAvroSchema(w io.Writer) error {
+	return s.Load().WriteAvroSchema(w)
+}
+
+// WriteGraphQLEnum is like BitfieldType.WriteGraphQLEnum.
+func (s *SyncBitfieldType) WriteGraphQLEnum(w io.Writer) error {
+	return s.Load().WriteGraphQLEnum(w)
+}
+
+// WriteOpenAPIComponent is like BitfieldType.WriteOpenAPIComponent.
+func (s *SyncBitfieldType) WriteOpenAPIComponent(w io.Writer) error {
+	return s.Load().WriteOpenAPIComponent(w)
+}
+
+// ToProtoDescriptor is like BitfieldType.ToProtoDescriptor.
+func (s *SyncBitfieldType) ToProtoDescriptor() (ProtoMessageDescriptor, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.ToProtoDescriptor()
+}
+
+// WriteProto is like BitfieldType.WriteProto.
+func (s *SyncBitfieldType) WriteProto(w io.Writer, packageName string) error {
+	return s.Load().WriteProto(w, packageName)
+}
+
+// WriteTypeScript is like BitfieldType.WriteTypeScript.
+func (s *SyncBitfieldType) WriteTypeScript(w io.Writer) error {
+	return s.Load().WriteTypeScript(w)
+}
+
+// }}}
@@ -0,0 +1,29 @@
+//go:build go1.21
+// +build go1.21
+
+package enumhelper
+
+import (
+	"log/slog"
+)
+
+// ToSlogAttr is like EnumType.ToSlogAttr.
+func (s *SyncEnumType) ToSlogAttr(key string, value uint) slog.Attr {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enum.ToSlogAttr(key, value)
+}
+
+// ToSlogAttr is like BitfieldType.ToSlogAttr.
+func (s *SyncBitfieldType) ToSlogAttr(key string, value uint64) slog.Attr {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.ToSlogAttr(key, value)
+}
+
+// ToSlogGroup is like BitfieldType.ToSlogGroup.
+func (s *SyncBitfieldType) ToSlogGroup(key string, value uint64) slog.Attr {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bitfield.ToSlogGroup(key, value)
+}
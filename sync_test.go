@@ -0,0 +1,156 @@
+package enumhelper
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSyncEnumType_Concurrent(t *testing.T) {
+	before := MakeEnumType("Color", testColorData[:2])
+	after := MakeEnumType("Color", testColorData)
+	s := before.WithRWMutex()
+
+	const numIterations = 100
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numIterations; i++ {
+			if i%2 == 0 {
+				s.Store(after)
+			} else {
+				s.Store(before)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numIterations; i++ {
+			if value, err := s.FromString("green"); err != nil || value != 1 {
+				t.Errorf("FromString: expected 1, nil; got %d, %v", value, err)
+				return
+			}
+			_ = s.ToString(3)
+		}
+	}()
+	wg.Wait()
+}
+
+func TestSyncEnumType_Store(t *testing.T) {
+	s := MakeEnumType("Color", testColorData[:2]).WithRWMutex()
+	resolve := s.MakeResolver()
+	if _, ok := resolve("blue"); ok {
+		t.Errorf("MakeResolver: expected blue to be unknown before Store")
+	}
+
+	// The callback may call Store, since ForEach does not hold the lock.
+	s.ForEach(func(data AnnotatedEnumData) {
+		s.Store(MakeEnumType("Color", testColorData))
+	})
+
+	if value, ok := resolve("blue"); !ok || value != 2 {
+		t.Errorf("MakeResolver: expected 2, true after Store; got %d, %v", value, ok)
+	}
+
+	hooked := s.WithValidationHook(rejectBlue)
+	if _, err := hooked.FromString("blue"); err != errNoBlue {
+		t.Errorf("WithValidationHook: expected %v, got %v", errNoBlue, err)
+	}
+	if _, err := s.FromString("blue"); err != nil {
+		t.Errorf("WithValidationHook: original changed: %v", err)
+	}
+}
+
+func TestSyncBitfieldType_Concurrent(t *testing.T) {
+	s := MakeBitfieldType("Perm", testPermData).WithRWMutex()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			s.Store(MakeBitfieldType("Perm", testPermData))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if value, err := s.FromString("read|write"); err != nil || value != 0x6 {
+				t.Errorf("FromString: expected 0x6, nil; got 0x%x, %v", value, err)
+				return
+			}
+			if count := s.PopCount(0x7); count != 3 {
+				t.Errorf("PopCount: expected 3, got %d", count)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+// storeWhileHookRuns calls parse, whose validation hook calls load, while
+// another goroutine is blocked in Store.  It fails the test if parse does not
+// return, which happens if parse holds the read lock while the hook runs.
+func storeWhileHookRuns(t *testing.T, hookEntered <-chan struct{}, store func(), parse func() error) {
+	t.Helper()
+
+	done := make(chan error, 1)
+	go func() { done <- parse() }()
+
+	<-hookEntered
+	storeDone := make(chan struct{})
+	go func() {
+		store()
+		close(storeDone)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("deadlock: hook called Load while Store was waiting")
+	}
+	<-storeDone
+}
+
+func TestSyncEnumType_HookCallsLoad(t *testing.T) {
+	var s *SyncEnumType
+	hookEntered := make(chan struct{})
+	var once sync.Once
+	s = MakeEnumType("Color", testColorData).WithValidationHook(func(value uint) error {
+		once.Do(func() { close(hookEntered) })
+		// Give Store time to start waiting for the write lock.
+		time.Sleep(10 * time.Millisecond)
+		_ = s.Load()
+		return nil
+	}).WithRWMutex()
+
+	storeWhileHookRuns(t, hookEntered,
+		func() { s.Store(MakeEnumType("Color", testColorData)) },
+		func() error {
+			_, err := s.FromString("green")
+			return err
+		})
+}
+
+func TestSyncBitfieldType_HookCallsLoad(t *testing.T) {
+	var s *SyncBitfieldType
+	hookEntered := make(chan struct{})
+	var once sync.Once
+	s = MakeBitfieldType("Perm", testPermData).WithValidationHook(func(value uint64) error {
+		once.Do(func() { close(hookEntered) })
+		time.Sleep(10 * time.Millisecond)
+		_ = s.Load()
+		return nil
+	}).WithRWMutex()
+
+	storeWhileHookRuns(t, hookEntered,
+		func() { s.Store(MakeBitfieldType("Perm", testPermData)) },
+		func() error {
+			_, err := s.FromJSON([]byte(`"read"`))
+			return err
+		})
+}
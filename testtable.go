@@ -0,0 +1,73 @@
+package enumhelper
+
+// EnumTestCase is one row of the table returned by EnumType.MakeTestTable.
+type EnumTestCase struct {
+	Name  string
+	Value uint
+}
+
+// EnumInputTestCase is one row of the table returned by
+// EnumType.MakeTestTableWithAliases.
+type EnumInputTestCase struct {
+	Input string
+	Value uint
+}
+
+// BitfieldTestCase is one row of the table returned by
+// BitfieldType.MakeTestTable.
+type BitfieldTestCase struct {
+	Name string
+	Bit  uint64
+}
+
+// MakeTestTable returns one row per value of this enum type, pairing the
+// value's canonical name with the value itself.  It is intended for
+// table-driven tests.
+func (enum EnumType) MakeTestTable() []EnumTestCase {
+	out := make([]EnumTestCase, 0, enum.Len())
+	enum.ForEach(func(data AnnotatedEnumData) {
+		out = append(out, EnumTestCase{
+			Name:  data.canonicalName(),
+			Value: data.Value,
+		})
+	})
+	return out
+}
+
+// MakeTestTableWithAliases returns one row for each GoName, Name, and alias
+// of each value of this enum type, pairing that string with the value.
+// Empty strings are omitted.  It is intended for table-driven tests which
+// check that every accepted spelling parses correctly.
+func (enum EnumType) MakeTestTableWithAliases() []EnumInputTestCase {
+	out := make([]EnumInputTestCase, 0, 2*enum.Len())
+	enum.ForEach(func(data AnnotatedEnumData) {
+		inputs := make([]string, 0, 2+len(data.Aliases))
+		inputs = append(inputs, data.GoName, data.Name)
+		inputs = append(inputs, data.Aliases...)
+		for _, input := range inputs {
+			if input != "" {
+				out = append(out, EnumInputTestCase{
+					Input: input,
+					Value: data.Value,
+				})
+			}
+		}
+	})
+	return out
+}
+
+// MakeTestTable returns one row per named bit of this bitfield type, pairing
+// the bit's canonical name with the bit's value.  It is intended for
+// table-driven tests.
+func (bitfield BitfieldType) MakeTestTable() []BitfieldTestCase {
+	out := make([]BitfieldTestCase, 0, len(bitfield.Names))
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		if name := data.canonicalName(); name != "" {
+			out = append(out, BitfieldTestCase{
+				Name: name,
+				Bit:  data.Bit,
+			})
+		}
+	})
+	return out
+}
@@ -0,0 +1,38 @@
+package enumhelper
+
+import (
+	"testing"
+)
+
+func TestEnumType_MakeTestTableWithAliases(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	expectLen := 0
+	for _, data := range testColorData {
+		expectLen += 2 + len(data.Aliases)
+	}
+
+	table := color.MakeTestTableWithAliases()
+	if len(table) != expectLen {
+		t.Fatalf("expected %d rows, got %d: %v", expectLen, len(table), table)
+	}
+
+	for _, row := range table {
+		if row.Input == "" {
+			t.Errorf("unexpected empty Input for value %d", row.Value)
+			continue
+		}
+		value, err := color.FromString(row.Input)
+		if err != nil || value != row.Value {
+			t.Errorf("FromString(%q): expected %d, nil; got %d, %v", row.Input, row.Value, value, err)
+		}
+	}
+
+	sparse := MakeEnumType("Sparse", []EnumData{
+		{Name: "alpha"},
+		{GoName: "SparseBeta", Aliases: []string{"b"}},
+	})
+	if table := sparse.MakeTestTableWithAliases(); len(table) != 3 {
+		t.Errorf("expected empty strings to be omitted, got %v", table)
+	}
+}
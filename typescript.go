@@ -0,0 +1,78 @@
+package enumhelper
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+)
+
+// maxSafeBitIndex is the highest bit index whose value can be represented
+// exactly by a JavaScript number.
+const maxSafeBitIndex = 52
+
+// WriteTypeScript writes a TypeScript union type of the canonical names of
+// this enum type's values, e.g.
+//
+//	export type Color = "red" | "green" | "blue";
+func (enum EnumType) WriteTypeScript(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString("export type ")
+	buf.WriteString(enum.Type)
+	buf.WriteString(" =")
+	sep := " "
+	enum.ForEach(func(data AnnotatedEnumData) {
+		name := data.canonicalName()
+		if name == "" {
+			return
+		}
+		buf.WriteString(sep)
+		buf.WriteString(jsonQuote(name))
+		sep = " | "
+	})
+	if sep == " " {
+		buf.WriteString(" never")
+	}
+	buf.WriteString(";\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteTypeScript writes a TypeScript const object mapping the names of this
+// bitfield type's bits to their values.
+//
+// Bits above index 52 cannot be represented exactly by a JavaScript number.
+// If any named bit is above index 52, every value is written as a BigInt
+// literal, since TypeScript does not allow mixing number and bigint operands
+// when combining flags.
+func (bitfield BitfieldType) WriteTypeScript(w io.Writer) error {
+	useBigInt := false
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		if data.Index > maxSafeBitIndex && data.canonicalName() != "" {
+			useBigInt = true
+		}
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString("export const ")
+	buf.WriteString(bitfield.Type)
+	buf.WriteString(" = {\n")
+	bitfield.ForEach(func(data AnnotatedBitfieldData) {
+		name := data.canonicalName()
+		if name == "" {
+			return
+		}
+		buf.WriteString("  ")
+		buf.WriteString(jsonQuote(name))
+		buf.WriteString(": 0x")
+		buf.WriteString(strconv.FormatUint(data.Bit, 16))
+		if useBigInt {
+			buf.WriteString("n")
+		}
+		buf.WriteString(",\n")
+	})
+	buf.WriteString("} as const;\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
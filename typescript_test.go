@@ -0,0 +1,91 @@
+package enumhelper
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	tsConstOpenRE  = regexp.MustCompile(`^export const [A-Za-z_$][A-Za-z0-9_$]* = \{$`)
+	tsConstCloseRE = regexp.MustCompile(`^\} as const;$`)
+	tsNumberRE     = regexp.MustCompile(`^  "[^"]+": 0x[0-9a-f]+,$`)
+	tsBigIntRE     = regexp.MustCompile(`^  "[^"]+": 0x[0-9a-f]+n,$`)
+	tsUnionRE      = regexp.MustCompile(`^export type [A-Za-z_$][A-Za-z0-9_$]* = (?:never|"[^"]+"(?: \| "[^"]+")*);\n$`)
+)
+
+// checkTypeScriptConst verifies that src is a well-formed const object whose
+// entries all match entryRE, and returns the number of entries.
+func checkTypeScriptConst(t *testing.T, src string, entryRE *regexp.Regexp) int {
+	t.Helper()
+	lines := strings.Split(strings.TrimSuffix(src, "\n"), "\n")
+	if len(lines) < 2 || !tsConstOpenRE.MatchString(lines[0]) || !tsConstCloseRE.MatchString(lines[len(lines)-1]) {
+		t.Fatalf("malformed const declaration %q", src)
+	}
+	entries := lines[1 : len(lines)-1]
+	for _, line := range entries {
+		if !entryRE.MatchString(line) {
+			t.Errorf("entry %q does not match %v", line, entryRE)
+		}
+	}
+	return len(entries)
+}
+
+func TestBitfieldType_WriteTypeScript(t *testing.T) {
+	var buf bytes.Buffer
+	perm := MakeBitfieldType("Perm", testPermData)
+	if err := perm.WriteTypeScript(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := checkTypeScriptConst(t, buf.String(), tsNumberRE); n != 3 {
+		t.Errorf("expected 3 entries, got %d in %q", n, buf.String())
+	}
+	if !strings.Contains(buf.String(), `"read": 0x4,`) {
+		t.Errorf("missing entry for read in %q", buf.String())
+	}
+}
+
+func TestBitfieldType_WriteTypeScript_BigInt(t *testing.T) {
+	in := make([]BitfieldData, 64)
+	copy(in, testPermData)
+	in[63] = BitfieldData{GoName: "PermTop", Name: "top"}
+
+	var buf bytes.Buffer
+	perm := MakeBitfieldType("Perm", in)
+	if err := perm.WriteTypeScript(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := checkTypeScriptConst(t, buf.String(), tsBigIntRE); n != 4 {
+		t.Errorf("expected 4 entries, got %d in %q", n, buf.String())
+	}
+	for _, entry := range []string{`"exec": 0x1n,`, `"top": 0x8000000000000000n,`} {
+		if !strings.Contains(buf.String(), entry) {
+			t.Errorf("missing entry %s in %q", entry, buf.String())
+		}
+	}
+}
+
+func TestEnumType_WriteTypeScript(t *testing.T) {
+	var buf bytes.Buffer
+	color := MakeEnumType("Color", testColorData)
+	if err := color.WriteTypeScript(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tsUnionRE.MatchString(buf.String()) {
+		t.Errorf("malformed type declaration %q", buf.String())
+	}
+	expected := `export type Color = "red" | "green" | "blue" | "purple";` + "\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	empty := MakeEnumType("Empty", nil)
+	if err := empty.WriteTypeScript(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "export type Empty = never;\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,219 @@
+package enumhelper
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var errNoType = errors.New("value has no associated type")
+
+// type EnumValue {{{
+
+// EnumValue pairs an enum value with its EnumType.  It implements
+// fmt.Stringer, fmt.GoStringer, encoding.TextMarshaler,
+// encoding.TextUnmarshaler, json.Marshaler, and json.Unmarshaler, so it can
+// be used directly as a struct field type.
+//
+// The zero EnumValue has no type and cannot be marshaled or unmarshaled; use
+// EnumType.Wrap to construct one.
+type EnumValue struct {
+	typ *EnumType
+	val uint
+}
+
+// Wrap returns an EnumValue for the given value of this enum type.  The
+// EnumValue holds its own copy of enum, so later changes to the caller's
+// EnumType variable do not affect it.
+func (enum EnumType) Wrap(value uint) EnumValue {
+	return EnumValue{typ: &enum, val: value}
+}
+
+// Type returns the EnumType of this value, or nil for the zero EnumValue.
+func (v EnumValue) Type() *EnumType {
+	return v.typ
+}
+
+// Value returns the numeric value.
+func (v EnumValue) Value() uint {
+	return v.val
+}
+
+// String fulfills fmt.Stringer.
+func (v EnumValue) String() string {
+	if v.typ == nil {
+		return strconv.FormatUint(uint64(v.val), 10)
+	}
+	return v.typ.ToString(v.val)
+}
+
+// GoString fulfills fmt.GoStringer.
+func (v EnumValue) GoString() string {
+	if v.typ == nil {
+		return fmt.Sprintf("EnumValue(%d)", v.val)
+	}
+	return v.typ.ToGoString(v.val)
+}
+
+// MarshalText fulfills encoding.TextMarshaler.
+func (v EnumValue) MarshalText() ([]byte, error) {
+	if v.typ == nil {
+		return nil, errNoType
+	}
+	if !v.typ.Contains(v.val) {
+		return nil, InvalidEnumValueError{
+			Type:  v.typ.Type,
+			Value: v.val,
+			Limit: v.typ.Len(),
+		}
+	}
+	return []byte(v.typ.ToString(v.val)), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (v *EnumValue) UnmarshalText(text []byte) error {
+	if v.typ == nil {
+		return errNoType
+	}
+	value, err := v.typ.Parse(string(text))
+	if err != nil {
+		return err
+	}
+	v.val = value
+	return nil
+}
+
+// MarshalJSON fulfills json.Marshaler.
+func (v EnumValue) MarshalJSON() ([]byte, error) {
+	if v.typ == nil {
+		return nil, errNoType
+	}
+	return v.typ.ToJSON(v.val)
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (v *EnumValue) UnmarshalJSON(raw []byte) error {
+	if v.typ == nil {
+		return errNoType
+	}
+	value, err := v.typ.FromJSON(raw)
+	if err != nil {
+		return err
+	}
+	v.val = value
+	return nil
+}
+
+var (
+	_ fmt.Stringer             = EnumValue{}
+	_ fmt.GoStringer           = EnumValue{}
+	_ encoding.TextMarshaler   = EnumValue{}
+	_ encoding.TextUnmarshaler = (*EnumValue)(nil)
+	_ json.Marshaler           = EnumValue{}
+	_ json.Unmarshaler         = (*EnumValue)(nil)
+)
+
+// }}}
+
+// type BitfieldValue {{{
+
+// BitfieldValue pairs a bitfield value with its BitfieldType.  It implements
+// fmt.Stringer, fmt.GoStringer, encoding.TextMarshaler,
+// encoding.TextUnmarshaler, json.Marshaler, and json.Unmarshaler, so it can
+// be used directly as a struct field type.
+//
+// The zero BitfieldValue has no type and cannot be marshaled or unmarshaled;
+// use BitfieldType.Wrap to construct one.
+type BitfieldValue struct {
+	typ *BitfieldType
+	val uint64
+}
+
+// Wrap returns a BitfieldValue for the given value of this bitfield type.
+// The BitfieldValue holds its own copy of bitfield, so later changes to the
+// caller's BitfieldType variable do not affect it.
+func (bitfield BitfieldType) Wrap(value uint64) BitfieldValue {
+	return BitfieldValue{typ: &bitfield, val: value}
+}
+
+// Type returns the BitfieldType of this value, or nil for the zero
+// BitfieldValue.
+func (v BitfieldValue) Type() *BitfieldType {
+	return v.typ
+}
+
+// Value returns the numeric value.
+func (v BitfieldValue) Value() uint64 {
+	return v.val
+}
+
+// String fulfills fmt.Stringer.
+func (v BitfieldValue) String() string {
+	if v.typ == nil {
+		return "0x" + strconv.FormatUint(v.val, 16)
+	}
+	return v.typ.ToString(v.val)
+}
+
+// GoString fulfills fmt.GoStringer.
+func (v BitfieldValue) GoString() string {
+	if v.typ == nil {
+		return fmt.Sprintf("BitfieldValue(0x%x)", v.val)
+	}
+	return v.typ.ToGoString(v.val)
+}
+
+// MarshalText fulfills encoding.TextMarshaler.
+func (v BitfieldValue) MarshalText() ([]byte, error) {
+	if v.typ == nil {
+		return nil, errNoType
+	}
+	return []byte(v.typ.ToString(v.val)), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (v *BitfieldValue) UnmarshalText(text []byte) error {
+	if v.typ == nil {
+		return errNoType
+	}
+	value, err := v.typ.FromString(string(text))
+	if err != nil {
+		return err
+	}
+	v.val = value
+	return nil
+}
+
+// MarshalJSON fulfills json.Marshaler.
+func (v BitfieldValue) MarshalJSON() ([]byte, error) {
+	if v.typ == nil {
+		return nil, errNoType
+	}
+	return v.typ.ToJSON(v.val)
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (v *BitfieldValue) UnmarshalJSON(raw []byte) error {
+	if v.typ == nil {
+		return errNoType
+	}
+	value, err := v.typ.FromJSON(raw)
+	if err != nil {
+		return err
+	}
+	v.val = value
+	return nil
+}
+
+var (
+	_ fmt.Stringer             = BitfieldValue{}
+	_ fmt.GoStringer           = BitfieldValue{}
+	_ encoding.TextMarshaler   = BitfieldValue{}
+	_ encoding.TextUnmarshaler = (*BitfieldValue)(nil)
+	_ json.Marshaler           = BitfieldValue{}
+	_ json.Unmarshaler         = (*BitfieldValue)(nil)
+)
+
+// }}}
@@ -0,0 +1,62 @@
+package enumhelper
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumType_Wrap(t *testing.T) {
+	v := MakeEnumType("Color", testColorData).Wrap(1)
+	if str := v.String(); str != "green" {
+		t.Errorf("String: expected %q, got %q", "green", str)
+	}
+	if str := v.GoString(); str != "ColorGreen" {
+		t.Errorf("GoString: expected %q, got %q", "ColorGreen", str)
+	}
+
+	type record struct {
+		Color EnumValue `json:"color"`
+	}
+	raw, err := json.Marshal(record{Color: v})
+	if err != nil || string(raw) != `{"color":"green"}` {
+		t.Errorf("json.Marshal: expected {\"color\":\"green\"}, nil; got %s, %v", raw, err)
+	}
+
+	color := MakeEnumType("Color", testColorData)
+	out := record{Color: color.Wrap(0)}
+	if err := json.Unmarshal([]byte(`{"color":"azure"}`), &out); err != nil || out.Color.Value() != 2 {
+		t.Errorf("json.Unmarshal: expected 2, nil; got %d, %v", out.Color.Value(), err)
+	}
+
+	// The EnumValue holds a copy, so reassigning color does not affect it.
+	w := color.Wrap(3)
+	color = MakeEnumType("Color", testColorData[:2])
+	if str := w.String(); str != "purple" {
+		t.Errorf("String after reassignment: expected %q, got %q", "purple", str)
+	}
+	if typ := w.Type(); typ == nil || typ.Len() != 4 {
+		t.Errorf("Type after reassignment: expected the original 4-value type, got %v", typ)
+	}
+}
+
+func TestBitfieldType_Wrap(t *testing.T) {
+	v := MakeBitfieldType("Perm", testPermData).Wrap(0x5)
+	if str, expected := v.String(), MakeBitfieldType("Perm", testPermData).ToString(0x5); str != expected {
+		t.Errorf("String: expected %q, got %q", expected, str)
+	}
+
+	perm := MakeBitfieldType("Perm", testPermData)
+	w := perm.Wrap(0)
+	if err := w.UnmarshalText([]byte("r|w")); err != nil || w.Value() != 0x6 {
+		t.Errorf("UnmarshalText: expected 0x6, nil; got 0x%x, %v", w.Value(), err)
+	}
+
+	// The BitfieldValue holds a copy, so reassigning perm does not affect it.
+	perm = perm.WithEncodedAs("integer")
+	if raw, err := w.MarshalJSON(); err != nil || raw[0] != '"' {
+		t.Errorf("MarshalJSON after reassignment: expected a JSON string, got %s, %v", raw, err)
+	}
+	if raw, err := perm.Wrap(0x6).MarshalJSON(); err != nil || string(raw) != "6" {
+		t.Errorf("MarshalJSON with integer encoding: expected 6, nil; got %s, %v", raw, err)
+	}
+}
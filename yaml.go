@@ -0,0 +1,172 @@
+package enumhelper
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	yamlNullTag = "!!null"
+	yamlIntTag  = "!!int"
+)
+
+// yamlScalar returns the scalar node held by node, descending into a document
+// node if necessary.
+func yamlScalar(typeName string, node *yaml.Node) (*yaml.Node, error) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.ScalarNode {
+		return nil, fmt.Errorf("line %d: cannot unmarshal non-scalar YAML value into %s", node.Line, typeName)
+	}
+	return node, nil
+}
+
+// yamlIntError reports a YAML integer which cannot be parsed as an enum value,
+// such as one which is negative or too large.
+func yamlIntError(typeName string, node *yaml.Node, err error) error {
+	return fmt.Errorf("line %d: cannot unmarshal YAML value %q into %s: %w", node.Line, node.Value, typeName, err)
+}
+
+// MarshalEnumToYAML marshals this enum value to YAML, for use in implementing
+// yaml.Marshaler.  The result is the canonical string representation.  It
+// may panic with InvalidEnumValueError if the enum value is out of range.
+func MarshalEnumToYAML(enumName string, enumData []EnumData, value uint) (interface{}, error) {
+	row := DereferenceEnumData(enumName, enumData, value)
+	return row.Name, nil
+}
+
+// UnmarshalEnumFromYAML unmarshals an enum value from YAML, for use in
+// implementing yaml.Unmarshaler.  The YAML value may be a string or an
+// integer.  Returns IsNullError, InvalidEnumNameError, or
+// InvalidEnumValueError if a YAML value was parsed but could not be
+// unmarshaled as an enum value, or a *strconv.NumError if a YAML integer is
+// negative or too large.
+func UnmarshalEnumFromYAML(enumName string, enumData []EnumData, node *yaml.Node) (uint, error) {
+	node, err := yamlScalar(enumName, node)
+	if err != nil {
+		return 0, err
+	}
+
+	switch node.ShortTag() {
+	case yamlNullTag:
+		return 0, IsNullError{}
+
+	case yamlIntTag:
+		limit := uint(len(enumData))
+		u64, err := strconv.ParseUint(node.Value, 0, 0)
+		if err != nil {
+			return 0, yamlIntError(enumName, node, err)
+		}
+		if uint(u64) >= limit {
+			return 0, InvalidEnumValueError{
+				Type:  enumName,
+				Value: uint(u64),
+				Limit: limit,
+			}
+		}
+		return uint(u64), nil
+
+	default:
+		return ParseEnum(enumName, enumData, node.Value)
+	}
+}
+
+// ToYAML marshals this enum value to YAML, for use in implementing
+// yaml.Marshaler.  The result is the canonical string representation.
+// Returns InvalidEnumValueError if the enum value is out of range.
+func (enum EnumType) ToYAML(value uint) (interface{}, error) {
+	if !enum.Contains(value) {
+		return nil, InvalidEnumValueError{
+			Type:  enum.Type,
+			Value: value,
+			Limit: enum.Len(),
+		}
+	}
+	return enum.ToString(value), nil
+}
+
+// FromYAML unmarshals an enum value from YAML, for use in implementing
+// yaml.Unmarshaler.  The YAML value may be a string or an integer.  Returns
+// IsNullError, InvalidEnumNameError, or InvalidEnumValueError if a YAML value
+// was parsed but could not be unmarshaled as an enum value, a
+// *strconv.NumError if a YAML integer is negative or too large, or the error
+// returned by the WithValidationHook function.
+func (enum EnumType) FromYAML(node *yaml.Node) (uint, error) {
+	node, err := yamlScalar(enum.Type, node)
+	if err != nil {
+		return 0, err
+	}
+
+	switch node.ShortTag() {
+	case yamlNullTag:
+		return 0, IsNullError{}
+
+	case yamlIntTag:
+		u64, err := strconv.ParseUint(node.Value, 0, 0)
+		if err != nil {
+			return 0, yamlIntError(enum.Type, node, err)
+		}
+		if !enum.Contains(uint(u64)) {
+			return 0, InvalidEnumValueError{
+				Type:  enum.Type,
+				Value: uint(u64),
+				Limit: enum.Len(),
+			}
+		}
+		return enum.validate(uint(u64))
+
+	default:
+		return enum.FromString(node.Value)
+	}
+}
+
+// ToYAML marshals this bitfield value to YAML, for use in implementing
+// yaml.Marshaler.  The result is a sequence of bit names; unnamed bits, if
+// any, are represented by a final hexadecimal item.
+func (bitfield BitfieldType) ToYAML(value uint64) (interface{}, error) {
+	if value == 0 {
+		return []string{}, nil
+	}
+	return bitfield.toStringPieces(value), nil
+}
+
+// FromYAML unmarshals a bitfield value from YAML, for use in implementing
+// yaml.Unmarshaler.  The YAML value may be a sequence of bit names, a single
+// pipe-delimited string, or an integer.  Returns IsNullError or
+// InvalidBitfieldNameError if a YAML value was parsed but could not be
+// unmarshaled as a bitfield value, or the error returned by the
+// WithValidationHook function.
+func (bitfield BitfieldType) FromYAML(node *yaml.Node) (uint64, error) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		node = node.Content[0]
+	}
+
+	if node.Kind == yaml.SequenceNode {
+		accum := uint64(0)
+		for _, item := range node.Content {
+			item, err := yamlScalar(bitfield.Type, item)
+			if err != nil {
+				return 0, err
+			}
+			u64, err := bitfield.parse(item.Value)
+			if err != nil {
+				return 0, err
+			}
+			accum |= u64
+		}
+		return bitfield.validate(accum)
+	}
+
+	node, err := yamlScalar(bitfield.Type, node)
+	if err != nil {
+		return 0, err
+	}
+
+	if node.ShortTag() == yamlNullTag {
+		return 0, IsNullError{}
+	}
+	return bitfield.FromString(node.Value)
+}
@@ -0,0 +1,36 @@
+package enumhelper
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestEnumType_FromYAML_BadInteger(t *testing.T) {
+	color := MakeEnumType("Color", testColorData)
+
+	for _, str := range []string{"-1", "-0x10"} {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(str), &node); err != nil {
+			t.Fatal(err)
+		}
+
+		var numErr *strconv.NumError
+		if _, err := color.FromYAML(&node); !errors.As(err, &numErr) {
+			t.Errorf("FromYAML(%s): expected *strconv.NumError, got %v", str, err)
+		}
+		if _, err := UnmarshalEnumFromYAML("Color", testColorData, &node); !errors.As(err, &numErr) {
+			t.Errorf("UnmarshalEnumFromYAML(%s): expected *strconv.NumError, got %v", str, err)
+		}
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(`7`), &node); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := color.FromYAML(&node); !errors.Is(err, ErrInvalidEnumValue) {
+		t.Errorf("FromYAML(7): expected %v, got %v", ErrInvalidEnumValue, err)
+	}
+}